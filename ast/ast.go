@@ -0,0 +1,115 @@
+// Package ast declares types used to represent syntax tree of T-SQL scripts.
+// This package is based on Go package "ast" for Go language syntax trees.
+package ast
+
+import "mssfmt/token"
+
+// Script represents whole T-SQL script. Script consists of batches which are
+// separated by GO command. Comments of the script are kept in Comments map.
+// Spelling contains words of the script which may be keywords in the order of
+// occurrence, so the case of keywords which aren't kept as words in the tree
+// can be preserved at each of their uses. Aliases contains positions of
+// aliases of SELECT columns, table sources and PIVOT operators, the only
+// places where optional AS keyword may be added or removed.
+type Script struct {
+	Batches  []*Batch
+	Comments CommentMap
+	Spelling []Word
+	Aliases  []token.Position
+}
+
+// Batch represents group of statements. Field Go is true when batch is ended by
+// GO command and Count is an optional argument of GO (GO 10).
+type Batch struct {
+	Statements []Statement
+	Go         bool
+	Count      string
+}
+
+// Statement represents single T-SQL statement. All statement nodes embed
+// Terminator.
+type Statement interface {
+	Terminated() bool
+	Terminate()
+	Pos() token.Position
+	End() token.Position
+	SetRange(start, end token.Position)
+	statementNode()
+}
+
+// Terminator keeps information whether statement was terminated by semicolon
+// and positions of the first and the last word of the statement. It's
+// embedded in all statement nodes.
+type Terminator struct {
+	Semicolon bool
+	Start     token.Position
+	Last      token.Position
+}
+
+// Terminated returns true if statement was terminated by semicolon.
+func (t *Terminator) Terminated() bool {
+	return t.Semicolon
+}
+
+// Terminate marks statement as terminated by semicolon.
+func (t *Terminator) Terminate() {
+	t.Semicolon = true
+}
+
+// Pos returns position of the first word of the statement.
+func (t *Terminator) Pos() token.Position {
+	return t.Start
+}
+
+// End returns position of the last word of the statement.
+func (t *Terminator) End() token.Position {
+	return t.Last
+}
+
+// SetRange sets positions of the first and the last word of the statement.
+func (t *Terminator) SetRange(start, end token.Position) {
+	t.Start, t.Last = start, end
+}
+
+// CreateMode is an enum for the way object is defined in T-SQL DDL statements.
+type CreateMode int
+
+const (
+	CREATE        CreateMode = iota // CREATE
+	ALTER                           // ALTER
+	CREATEORALTER                   // CREATE OR ALTER
+)
+
+// RawStatement represents statement which isn't supported by the parser (yet).
+// It's kept as flat list of words so it can be printed back unchanged. Field
+// Complete is true for INSERT, DELETE and MERGE statements which end with
+// a complete clause, so their ends don't depend on the following words.
+type RawStatement struct {
+	Terminator
+	Words    Expression
+	Complete bool
+}
+
+// EmptyStatement represents standalone semicolon, for example the leading
+// semicolon in ";WITH cte AS (...)" idiom at the beginning of a batch.
+type EmptyStatement struct {
+	Terminator
+}
+
+// BlockStatement represents BEGIN ... END block of statements.
+type BlockStatement struct {
+	Terminator
+	Statements []Statement
+}
+
+// DataType represents T-SQL data type, like INT, NVARCHAR(MAX),
+// DECIMAL(18, 2) or user defined type dbo.TableType. Params are words between
+// parentheses without separating commas.
+type DataType struct {
+	Name   string
+	Params []Word
+}
+
+func (*RawStatement) statementNode()   {}
+func (*EmptyStatement) statementNode() {}
+func (*BlockStatement) statementNode() {}
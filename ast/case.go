@@ -0,0 +1,34 @@
+package ast
+
+import "mssfmt/token"
+
+// CaseExpr represents simple or searched CASE expression. It's kept in CASE
+// keyword of flat expression. Nested CASE expressions are part of conditions
+// and results and their CASE keywords keep their structure as well.
+//
+//	Simple CASE expression:
+//	CASE input_expression
+//	     WHEN when_expression THEN result_expression [ ...n ]
+//	     [ ELSE else_result_expression ]
+//	END
+//
+//	Searched CASE expression:
+//	CASE
+//	     WHEN boolean_expression THEN result_expression [ ...n ]
+//	     [ ELSE else_result_expression ]
+//	END
+//
+// Input is nil for searched CASE expression and Else is nil when there's no
+// ELSE branch. End is position of END keyword.
+type CaseExpr struct {
+	Input Expression
+	Whens []*WhenClause
+	Else  Expression
+	End   token.Position
+}
+
+// WhenClause represents single WHEN ... THEN ... branch of CASE expression.
+type WhenClause struct {
+	Condition Expression
+	Result    Expression
+}
@@ -0,0 +1,75 @@
+package ast
+
+import (
+	"mssfmt/token"
+	"sort"
+)
+
+// Node represents a node of the syntax tree with which comments can be
+// associated: *Script, *Batch or a Statement.
+type Node interface{}
+
+// Comment represents single line comment (-- ...) or block comment
+// (/* ... */). Text contains comment markers.
+type Comment struct {
+	Text string
+	Pos  token.Position
+}
+
+// CommentGroup represents sequence of comments with no other words between
+// them. Field Newline is true when the first comment of the group isn't
+// preceded by other word in the same line.
+type CommentGroup struct {
+	Kind    CommentKind
+	Newline bool
+	List    []*Comment
+}
+
+// CommentKind is an enum for the placement of comment group relative to the
+// node with which it's associated.
+type CommentKind int
+
+const (
+	LEADINGCOMMENT  CommentKind = iota // in lines before the node
+	TRAILINGCOMMENT                    // after the last word of the node
+	INNERCOMMENT                       // between the first and the last word
+)
+
+// CommentMap maps nodes of the syntax tree to comment groups associated with
+// them, similar to CommentMap from Go package "ast". Comments in the line of
+// the preceding word are trailing comments of the innermost statement which
+// ends at that word or inner comments of the innermost statement containing
+// them. Other comments are leading comments of the outermost statement which
+// starts right after them or inner comments of the innermost statement
+// containing them. Comments after the last statement of a batch are trailing
+// comments of the batch and comments after the last word of the script are
+// trailing comments of the script.
+type CommentMap map[Node][]*CommentGroup
+
+// Method Filter returns comment groups of given kind associated with node.
+func (cmap CommentMap) Filter(node Node, kind CommentKind) []*CommentGroup {
+	groups := make([]*CommentGroup, 0, len(cmap[node]))
+	for _, group := range cmap[node] {
+		if group.Kind == kind {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// Method Comments returns all comment groups of the map ordered by position.
+func (cmap CommentMap) Comments() []*CommentGroup {
+	groups := make([]*CommentGroup, 0, len(cmap))
+	for _, list := range cmap {
+		groups = append(groups, list...)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Pos().Offset < groups[j].Pos().Offset
+	})
+	return groups
+}
+
+// Method Pos returns position of the first comment of the group.
+func (group *CommentGroup) Pos() token.Position {
+	return group.List[0].Pos
+}
@@ -0,0 +1,59 @@
+package ast
+
+// IfStatement represents IF ... ELSE statement. Then and Else are single
+// statements, usually BEGIN ... END blocks. Else is nil when there's no ELSE
+// branch.
+//
+//	IF boolean_expression
+//	    { sql_statement | statement_block }
+//	[ ELSE
+//	    { sql_statement | statement_block } ]
+type IfStatement struct {
+	Terminator
+	Condition Expression
+	Then      Statement
+	Else      Statement
+}
+
+// WhileStatement represents WHILE loop. Body is a single statement, usually
+// BEGIN ... END block.
+//
+//	WHILE boolean_expression
+//	    { sql_statement | statement_block | BREAK | CONTINUE }
+type WhileStatement struct {
+	Terminator
+	Condition Expression
+	Body      Statement
+}
+
+// BreakStatement represents BREAK statement which exits the innermost WHILE
+// loop.
+type BreakStatement struct {
+	Terminator
+}
+
+// ContinueStatement represents CONTINUE statement which restarts the innermost
+// WHILE loop.
+type ContinueStatement struct {
+	Terminator
+}
+
+// GotoStatement represents GOTO statement which jumps to the Label.
+type GotoStatement struct {
+	Terminator
+	Label string
+}
+
+// LabelStatement represents definition of label (target of GOTO statement)
+// in form "label:". Label doesn't contain the colon.
+type LabelStatement struct {
+	Terminator
+	Label string
+}
+
+func (*IfStatement) statementNode()       {}
+func (*WhileStatement) statementNode()    {}
+func (*BreakStatement) statementNode()    {}
+func (*ContinueStatement) statementNode() {}
+func (*GotoStatement) statementNode()     {}
+func (*LabelStatement) statementNode()    {}
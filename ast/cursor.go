@@ -0,0 +1,76 @@
+package ast
+
+// DeclareCursorStatement represents declaration of a named cursor. From SQL
+// Server 2019 documentation:
+//
+//	DECLARE cursor_name [ INSENSITIVE ] [ SCROLL ] CURSOR
+//	    FOR select_statement
+//	    [ FOR { READ ONLY | UPDATE [ OF column_name [ ,...n ] ] } ]
+//
+//	DECLARE cursor_name CURSOR [ LOCAL | GLOBAL ]
+//	    [ FORWARD_ONLY | SCROLL ]
+//	    [ STATIC | KEYSET | DYNAMIC | FAST_FORWARD ]
+//	    [ READ_ONLY | SCROLL_LOCKS | OPTIMISTIC ]
+//	    [ TYPE_WARNING ]
+//	    FOR select_statement
+//	    [ FOR UPDATE [ OF column_name [ ,...n ] ] ]
+//
+// ISOOptions are options before CURSOR keyword (ISO syntax) and Options are
+// options after it (Transact-SQL extended syntax). Query is nil when the
+// query isn't SELECT statement. For contains words of the trailing FOR
+// clause without FOR keyword, like READ ONLY or UPDATE OF a, b.
+type DeclareCursorStatement struct {
+	Terminator
+	Name       string
+	ISOOptions []string
+	Options    []string
+	Query      *SelectStatement
+	For        Expression
+}
+
+// CursorStatement represents OPEN, CLOSE or DEALLOCATE statement of a cursor.
+//
+//	{ OPEN | CLOSE | DEALLOCATE } { { [ GLOBAL ] cursor_name } | cursor_variable_name }
+type CursorStatement struct {
+	Terminator
+	Kind   CursorStatementKind
+	Global bool
+	Cursor string
+}
+
+// CursorStatementKind is an enum for kinds of cursor statements.
+type CursorStatementKind int
+
+const (
+	OPENCURSOR CursorStatementKind = iota
+	CLOSECURSOR
+	DEALLOCATECURSOR
+)
+
+// FetchStatement represents FETCH statement which retrieves a row from
+// cursor.
+//
+//	FETCH
+//	    [ [ NEXT | PRIOR | FIRST | LAST
+//	        | ABSOLUTE { n | @nvar }
+//	        | RELATIVE { n | @nvar }
+//	      ]
+//	      FROM
+//	    ]
+//	    { { [ GLOBAL ] cursor_name } | @cursor_variable_name }
+//	    [ INTO @variable_name [ ,...n ] ]
+//
+// Orientation contains words before FROM keyword, like NEXT or ABSOLUTE 10.
+// From is true when FROM keyword was used. Into contains names of variables.
+type FetchStatement struct {
+	Terminator
+	Orientation Expression
+	From        bool
+	Global      bool
+	Cursor      string
+	Into        []string
+}
+
+func (*DeclareCursorStatement) statementNode() {}
+func (*CursorStatement) statementNode()        {}
+func (*FetchStatement) statementNode()         {}
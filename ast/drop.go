@@ -0,0 +1,27 @@
+package ast
+
+// DropStatement represents DROP statement of tables, views, procedures,
+// functions, indexes and triggers. From SQL Server 2019 documentation:
+//
+//	DROP TABLE [ IF EXISTS ] { database_name.schema_name.table_name | schema_name.table_name | table_name } [ ,...n ]
+//	DROP VIEW [ IF EXISTS ] [ schema_name . ] view_name [ ...,n ]
+//	DROP { PROC | PROCEDURE } [ IF EXISTS ] { [ schema_name. ] procedure } [ ,...n ]
+//	DROP FUNCTION [ IF EXISTS ] { [ schema_name. ] function_name } [ ,...n ]
+//	DROP TRIGGER [ IF EXISTS ] [schema_name.]trigger_name [ ,...n ] [ ON { DATABASE | ALL SERVER } ]
+//	DROP INDEX [ IF EXISTS ]
+//	    { <drop_relational_or_xml_or_spatial_index> [ ,...n ]
+//	    | <drop_backward_compatible_index> [ ,...n ] }
+//
+//	<drop_relational_or_xml_or_spatial_index> ::=
+//	    index_name ON <object> [ WITH ( <drop_clustered_index_option> [ ,...n ] ) ]
+//
+// Object contains the type of dropped objects (as written). Each dropped
+// object is kept as an expression, for example "ix ON dbo.t".
+type DropStatement struct {
+	Terminator
+	Object   string
+	IfExists bool
+	Names    []Expression
+}
+
+func (*DropStatement) statementNode() {}
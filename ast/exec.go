@@ -0,0 +1,51 @@
+package ast
+
+import "mssfmt/token"
+
+// ExecStatement represents EXEC or EXECUTE statement which executes stored
+// procedure, function or dynamic SQL string. From SQL Server 2019
+// documentation (simplified):
+//
+//	{ EXEC | EXECUTE }
+//	    [ @return_status = ]
+//	    { module_name [ ;number ] | @module_name_var }
+//	    [ [ @parameter = ] { value | @variable [ OUTPUT ] | [ DEFAULT ] } ]
+//	    [ ,...n ]
+//	    [ WITH <execute_option> [ ,...n ] ]
+//
+//	{ EXEC | EXECUTE }
+//	    ( { @string_variable | [ N ]'tsql_string' } [ + ...n ]
+//	      [ { , { value | @variable [ OUTPUT ] } } [ ...n ] ] )
+//	    [ AS { LOGIN | USER } = ' name ' ]
+//	    [ AT linked_server_name ]
+//	    [ AT DATA_SOURCE data_source_name ]
+//
+// Keyword contains EXEC or EXECUTE (as written). For dynamic SQL (second
+// form) Dynamic is true, Name is empty and Args contain items in parentheses,
+// the first one is the SQL string. Context contains AS LOGIN or AS USER
+// clause and At contains words after AT keyword. Options are items of WITH
+// clause, like RECOMPILE or RESULT SETS (...).
+type ExecStatement struct {
+	Terminator
+	Keyword      string
+	ReturnStatus string
+	Name         string
+	Dynamic      bool
+	Args         []*ExecArgument
+	Options      []Expression
+	Context      Expression
+	At           Expression
+}
+
+// ExecArgument represents single argument of EXEC statement. Name is empty
+// for positional arguments and Output contains OUT or OUTPUT keyword (as
+// written) for output parameters. End is position of the last word of the
+// argument.
+type ExecArgument struct {
+	Name   string
+	Value  Expression
+	Output string
+	End    token.Position
+}
+
+func (*ExecStatement) statementNode() {}
@@ -0,0 +1,61 @@
+package ast
+
+// SystemTimeClause represents FOR SYSTEM_TIME clause of temporal table in FROM
+// clause.
+//
+//	FOR SYSTEM_TIME
+//	{
+//	       AS OF <date_time>
+//	    |  FROM <start_date_time> TO <end_date_time>
+//	    |  BETWEEN <start_date_time> AND <end_date_time>
+//	    |  CONTAINED IN (<start_date_time> , <end_date_time>)
+//	    |  ALL
+//	}
+//
+// Start is the only point in time for ASOF and both Start and End are nil for
+// ALLTIME.
+type SystemTimeClause struct {
+	Kind  SystemTimeKind
+	Start Expression
+	End   Expression
+}
+
+// SystemTimeKind is an enum for variants of FOR SYSTEM_TIME clause.
+type SystemTimeKind int
+
+const (
+	ASOF SystemTimeKind = iota
+	FROMTO
+	BETWEENAND
+	CONTAINEDIN
+	ALLTIME
+)
+
+// ForClause represents FOR XML, FOR JSON or FOR BROWSE clause of SELECT query.
+//
+//	[ FOR { BROWSE | <XML> | <JSON> } ]
+//	<XML> ::=
+//	XML
+//	{
+//	    { RAW [ ( 'ElementName' ) ] | AUTO }
+//	        [ , { XMLDATA | XMLSCHEMA [ ( 'TargetNameSpaceURI' ) ] }
+//	          [ , ELEMENTS [ XSINIL | ABSENT ] ] ... ]
+//	  | EXPLICIT [ , XMLDATA ] ...
+//	  | PATH [ ( 'ElementName' ) ] [ , ELEMENTS [ XSINIL | ABSENT ] ] ...
+//	}
+//	[ , BINARY BASE64 ] [ , TYPE ] [ , ROOT [ ( 'RootName' ) ] ]
+//	<JSON> ::=
+//	JSON
+//	{
+//	    { AUTO | PATH }
+//	        [ , ROOT [ ( 'RootName' ) ] ]
+//	        [ , INCLUDE_NULL_VALUES ]
+//	        [ , WITHOUT_ARRAY_WRAPPER ]
+//	}
+//
+// Mode contains XML, JSON or BROWSE (as written). Options are comma-separated
+// directives, the first one is a mode like PATH('row') or AUTO.
+type ForClause struct {
+	Mode    string
+	Options []Expression
+}
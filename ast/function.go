@@ -0,0 +1,72 @@
+package ast
+
+// FunctionStatement represents CREATE or ALTER FUNCTION statement.
+// From SQL Server 2019 documentation:
+//
+//	-- Scalar function
+//	CREATE [ OR ALTER ] FUNCTION [ schema_name. ] function_name
+//	( [ { @parameter_name [ AS ][ type_schema_name. ] parameter_data_type
+//	    [ = default ] [ READONLY ] }
+//	    [ ,...n ]
+//	  ]
+//	)
+//	RETURNS return_data_type
+//	    [ WITH <function_option> [ ,...n ] ]
+//	    [ AS ]
+//	    BEGIN
+//	        function_body
+//	        RETURN scalar_expression
+//	    END
+//
+//	-- Inline table-valued function
+//	... RETURNS TABLE
+//	    [ WITH <function_option> [ ,...n ] ]
+//	    [ AS ]
+//	    RETURN [ ( ] select_stmt [ ) ]
+//
+//	-- Multi-statement table-valued function
+//	... RETURNS @return_variable TABLE <table_type_definition>
+//	    [ WITH <function_option> [ ,...n ] ]
+//	    [ AS ]
+//	    BEGIN
+//	        function_body
+//	        RETURN
+//	    END
+//
+// ReturnType is set only for scalar functions, ReturnVariable and ReturnTable
+// only for multi-statement table-valued functions.
+type FunctionStatement struct {
+	Terminator
+	Mode           CreateMode
+	Kind           FunctionKind
+	Name           string
+	Parameters     []*Parameter
+	ReturnType     *DataType
+	ReturnVariable string
+	ReturnTable    *TableDefinition
+	Options        []Expression
+	ASKeyword      bool
+	Body           []Statement
+}
+
+// FunctionKind is an enum for kinds of T-SQL user defined functions.
+type FunctionKind int
+
+const (
+	SCALAR              FunctionKind = iota // RETURNS data_type
+	INLINETABLE                             // RETURNS TABLE
+	MULTISTATEMENTTABLE                     // RETURNS @variable TABLE (...)
+)
+
+// ReturnStatement represents RETURN statement with optional value. RETURN of
+// inline table-valued function has Query instead of Value and Parens says if
+// the query is written in parentheses.
+type ReturnStatement struct {
+	Terminator
+	Value  Expression
+	Query  *SelectStatement
+	Parens bool
+}
+
+func (*FunctionStatement) statementNode() {}
+func (*ReturnStatement) statementNode()   {}
@@ -0,0 +1,39 @@
+package ast
+
+// CreateIndexStatement represents CREATE INDEX statement for rowstore and
+// columnstore indexes. From SQL Server 2019 documentation (simplified):
+//
+//	CREATE [ UNIQUE ] [ CLUSTERED | NONCLUSTERED ] [ COLUMNSTORE ] INDEX index_name
+//	    ON <object> [ ( column [ ASC | DESC ] [ ,...n ] ) ]
+//	    [ INCLUDE ( column_name [ ,...n ] ) ]
+//	    [ WHERE <filter_predicate> ]
+//	    [ WITH ( <relational_index_option> [ ,...n ] ) ]
+//	    [ ON { partition_scheme_name ( column_name )
+//	         | filegroup_name
+//	         | default
+//	         }
+//	    ]
+//	    [ FILESTREAM_ON { filestream_filegroup_name | partition_scheme_name | "NULL" } ]
+//
+// Clustered contains CLUSTERED or NONCLUSTERED keyword (as written) and it's
+// empty otherwise. Columns are key columns with optional ASC or DESC, they are
+// nil for clustered columnstore index. Where is a filter predicate without
+// WHERE keyword and Options are items of WITH clause. Rest contains the words
+// after ON clause (like FILESTREAM_ON) or index options in the deprecated
+// form without parentheses.
+type CreateIndexStatement struct {
+	Terminator
+	Unique      bool
+	Clustered   string
+	Columnstore bool
+	Name        string
+	Table       string
+	Columns     []Expression
+	Include     []string
+	Where       Expression
+	Options     []Expression
+	FileGroup   Expression
+	Rest        Expression
+}
+
+func (*CreateIndexStatement) statementNode() {}
@@ -0,0 +1,22 @@
+package ast
+
+// PivotClause represents PIVOT or UNPIVOT operator applied to table source.
+//
+//	<pivot_clause> ::=
+//	    ( aggregate_function ( value_column [ [ , ]...n ] )
+//	    FOR pivot_column
+//	    IN ( <column_list> ) )
+//
+//	<unpivot_clause> ::=
+//	    ( value_column FOR pivot_column IN ( <column_list> ) )
+//
+// Value contains aggregate function call for PIVOT or value column for
+// UNPIVOT. Alias is required by SQL Server, but it's kept empty when omitted.
+type PivotClause struct {
+	Unpivot   bool
+	Value     Expression
+	For       string
+	In        []string
+	ASKeyword bool
+	Alias     string
+}
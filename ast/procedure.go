@@ -0,0 +1,52 @@
+package ast
+
+import "mssfmt/token"
+
+// ProcedureStatement represents CREATE or ALTER PROCEDURE statement.
+// From SQL Server 2019 documentation:
+//
+//	CREATE [ OR ALTER ] { PROC | PROCEDURE }
+//	    [schema_name.] procedure_name [ ; number ]
+//	    [ { @parameter [ type_schema_name. ] data_type }
+//	        [ VARYING ] [ = default ] [ OUT | OUTPUT | [READONLY]
+//	    ] [ ,...n ]
+//	[ WITH <procedure_option> [ ,...n ] ]
+//	[ FOR REPLICATION ]
+//	AS { [ BEGIN ] sql_statement [;] [ ...n ] [ END ] }
+//
+//	<procedure_option> ::=
+//	    [ ENCRYPTION ]
+//	    [ RECOMPILE ]
+//	    [ EXECUTE AS Clause ]
+//
+// Field Proc is true when short PROC keyword was used. Parens is true when
+// parameters are enclosed in parentheses. Body contains all statements after
+// AS keyword till the end of the batch.
+type ProcedureStatement struct {
+	Terminator
+	Mode           CreateMode
+	Proc           bool
+	Name           string
+	Parens         bool
+	Parameters     []*Parameter
+	Options        []Expression
+	ForReplication bool
+	Body           []Statement
+}
+
+// Parameter represents single parameter of stored procedure or function.
+// Output contains OUT or OUTPUT keyword (as it was written) in case of output
+// parameter and it's empty otherwise. End is position of the last word of the
+// parameter.
+type Parameter struct {
+	Name      string
+	ASKeyword bool
+	Type      *DataType
+	Varying   bool
+	Default   Expression
+	Output    string
+	ReadOnly  bool
+	End       token.Position
+}
+
+func (*ProcedureStatement) statementNode() {}
@@ -1,9 +1,51 @@
 package ast
 
-import "mssfmt/token"
+import (
+	"mssfmt/token"
+	"unicode/utf8"
+)
 
-// SelectQuery represents AST for SELECT query. TODO...
-// From SQL Server 2019 documentation:
+// SelectStatement represents complete SELECT statement - optional common table
+// expressions and one or more queries combined by set operators (UNION,
+// EXCEPT, INTERSECT). From SQL Server 2019 documentation:
+//
+//	<SELECT statement> ::=
+//	    [ WITH { [ XMLNAMESPACES ,] [ <common_table_expression> [,...n] ] } ]
+//	    <query_expression>
+//	    [ ORDER BY <order_by_expression> ]
+//	    [ <FOR Clause>]
+//	    [ OPTION ( <query_hint> [ ,...n ] ) ]
+//	<query_expression> ::=
+//	    { <query_specification> | ( <query_expression> ) }
+//	    [  { UNION [ ALL ] | EXCEPT | INTERSECT }
+//	        <query_specification> | ( <query_expression> ) [...n ] ]
+//
+// ORDER BY and OPTION clauses are kept in the last query of the statement.
+type SelectStatement struct {
+	Terminator
+	With   []*CommonTableExpr
+	Query  *SelectQuery
+	SetOps []*SetOperation
+}
+
+// CommonTableExpr represents single common table expression in WITH clause,
+// like "cte (Col1, Col2) AS (SELECT ...)".
+type CommonTableExpr struct {
+	Name    string
+	Columns []string
+	Query   *SelectStatement
+}
+
+// SetOperation represents query combined with previous queries by UNION [ALL],
+// EXCEPT or INTERSECT operator.
+type SetOperation struct {
+	Operator token.Token
+	All      bool
+	Query    *SelectQuery
+}
+
+// SelectQuery represents AST for SELECT query. From SQL Server 2019
+// documentation:
 //
 //	SELECT [ ALL | DISTINCT ]
 //	    [TOP ( expression ) [PERCENT] [ WITH TIES ] ]
@@ -13,6 +55,10 @@ import "mssfmt/token"
 //	    [ WHERE <search_condition> ]
 //	    [ <GROUP BY> ]
 //	    [ HAVING < search_condition > ]
+//	    [ WINDOW window_name AS ( <window_spec> ) [ ,...n ] ]
+//	    [ ORDER BY <order_by_expression> ]
+//	    [ FOR { BROWSE | <XML> | <JSON> } ]
+//	    [ OPTION ( <query_hint> [ ,...n ] ) ]
 //
 type SelectQuery struct {
 	DistinctType *DistinctType
@@ -23,19 +69,54 @@ type SelectQuery struct {
 	Where        *WhereClause
 	GroupBy      *GroupByClause
 	Having       *HavingClause
+	Window       []*WindowDefinition
+	OrderBy      *OrderByClause
+	For          *ForClause
 	Options      *SelectOptions
 }
 
 // Word represents single "word" in SQL script. It's a pair of token.Token and
-// corresponding literal.
+// corresponding literal together with position of the word in the script.
+// CASE keyword of parsed expression also keeps structure of its CASE
+// expression and OVER keyword keeps its window specification. Their words
+// still follow the keyword in the flat expression.
 type Word struct {
 	Token   token.Token
 	Literal string
+	Pos     token.Position
+	Case    *CaseExpr
+	Window  *WindowSpec
 }
 
 // T-SQL expression is just a slice of Words.
 type Expression []Word
 
+// Method Touches checks if there wasn't any whitespace between the word and
+// the next word in the script. Words without valid positions never touch.
+func (w Word) Touches(next Word) bool {
+	return w.Pos.IsValid() && next.Pos.IsValid() &&
+		w.Pos.Line == next.Pos.Line &&
+		w.Pos.Column+utf8.RuneCountInString(w.Literal) == next.Pos.Column
+}
+
+// Method ClosingParen returns index of parenthesis which closes the one at
+// index open. Length of the expression is returned when it isn't closed.
+func (expr Expression) ClosingParen(open int) int {
+	depth := 0
+	for id := open; id < len(expr); id++ {
+		switch expr[id].Token {
+		case token.LPAREN:
+			depth++
+		case token.RPAREN:
+			depth--
+			if depth == 0 {
+				return id
+			}
+		}
+	}
+	return len(expr)
+}
+
 // DistinctType represents [ALL | DISTINCT | ] clause in SELECT query.
 // Both All and Distinct can be false but they both cannot be true at the same
 // time - this is invalid query.
@@ -130,25 +211,41 @@ type FromClause struct {
 	Joins           []SQLJoin
 }
 
-// TableName represents single table or view name with some properties like
-// alias, sample clause and table hints.
+// TableName represents single table source with some properties like alias,
+// sample clause and table hints. Table source is a table or view name, call
+// of table-valued function (when Args isn't nil), derived table (Subquery) or
+// other source kept as flat expression (Source), like VALUES constructor or
+// joined tables in parentheses. Schema contains column definitions of WITH
+// clause of OPENJSON and OPENXML functions, which is placed before the alias.
+// SystemTime is set for temporal tables queried with FOR SYSTEM_TIME clause. Pivots contains PIVOT and UNPIVOT operators
+// applied to the source in the order of occurrence. End is position of the
+// last word of the table source.
 type TableName struct {
-	Name      string
-	ASKeyword bool
-	Alias     *string
-	Sample    *tableSampleClause
-	Hints     *tableHints
+	Name       string
+	Args       []Expression
+	Schema     []Expression
+	SystemTime *SystemTimeClause
+	Subquery   *SelectStatement
+	Source     Expression
+	ASKeyword  bool
+	Alias      *string
+	Columns    []string
+	Sample     *TableSampleClause
+	Hints      *TableHints
+	Pivots     []*PivotClause
+	End        token.Position
 }
 
 // <tablesample_clause> ::=
 //     TABLESAMPLE [SYSTEM] ( sample_number [ PERCENT | ROWS ] )
 //         [ REPEATABLE ( repeat_seed ) ]
-type tableSampleClause struct {
-	System       *string
-	SampleNumber int
+type TableSampleClause struct {
+	System       bool
+	SampleNumber Expression
 	Perc         bool
+	Rows         bool
 	Repeatable   bool
-	RepSeed      int
+	RepSeed      Expression
 }
 
 // WITH  ( <table_hint> [ [, ]...n ] )
@@ -178,7 +275,7 @@ type tableSampleClause struct {
 //   | UPDLOCK
 //   | XLOCK
 // }
-type tableHints struct {
+type TableHints struct {
 	Hints []Expression
 }
 
@@ -218,12 +315,46 @@ const (
 	LEFTOUTER
 	RIGHTOUTER
 	FULLOUTER
+	JOIN       // JOIN without join type
+	CROSSAPPLY // CROSS APPLY
+	OUTERAPPLY // OUTER APPLY
+	COMMA      // table source separated by comma
 )
 
-// SQLJoinHints contains
-type SQLJoinHints struct{}
+// SQLJoinHints contains join hint (LOOP, HASH, MERGE or REMOTE) placed
+// between join type and JOIN keyword. Hint is empty if it isn't specified.
+type SQLJoinHints struct {
+	Hint string
+}
+
+// WhereClause represents WHERE clause. Search condition is kept as flat
+// expression.
+type WhereClause struct {
+	Condition Expression
+}
+
+// GroupByClause represents GROUP BY clause with its comma-separated items.
+type GroupByClause struct {
+	Items []Expression
+}
+
+// HavingClause represents HAVING clause. Search condition is kept as flat
+// expression.
+type HavingClause struct {
+	Condition Expression
+}
+
+// OrderByClause represents ORDER BY clause with its comma-separated items and
+// optional OFFSET ... FETCH ... expression.
+type OrderByClause struct {
+	Items  []Expression
+	Offset Expression
+}
+
+// SelectOptions represents OPTION clause with query hints, like
+// "OPTION (RECOMPILE, MAXDOP 1)".
+type SelectOptions struct {
+	Hints []Expression
+}
 
-type WhereClause struct{}
-type GroupByClause struct{}
-type HavingClause struct{}
-type SelectOptions struct{}
+func (*SelectStatement) statementNode() {}
@@ -0,0 +1,217 @@
+package ast
+
+import "mssfmt/token"
+
+// CreateTableStatement represents CREATE TABLE statement. From SQL Server 2019
+// documentation (simplified):
+//
+//	CREATE TABLE
+//	    { database_name.schema_name.table_name | schema_name.table_name | table_name }
+//	    ( { <column_definition> | <computed_column_definition>
+//	        | <table_constraint> | <table_index> } [ ,...n ] )
+//	    [ ON { partition_scheme_name ( partition_column_name )
+//	           | filegroup | "default" } ]
+//	    [ TEXTIMAGE_ON { filegroup | "default" } ]
+//	    [ FILESTREAM_ON { partition_scheme_name | filegroup | "default" } ]
+//	    [ WITH ( <table_option> [ ,...n ] ) ]
+//
+// FileGroup contains words after ON keyword and Options contains the rest of
+// the statement (TEXTIMAGE_ON, FILESTREAM_ON and WITH clauses).
+type CreateTableStatement struct {
+	Terminator
+	Name       string
+	Definition *TableDefinition
+	FileGroup  Expression
+	Options    Expression
+}
+
+// TableDefinition represents definition of table columns and constraints in
+// parentheses, used in CREATE TABLE, table variables and multi-statement
+// table-valued functions. Elements are comma-separated column definitions,
+// table constraints and other elements kept as RawTableElement.
+type TableDefinition struct {
+	Elements []TableElement
+}
+
+// TableElement is a common interface for elements of table definition.
+// Method End returns position of the last word of the element.
+type TableElement interface {
+	tableElement()
+	End() token.Position
+}
+
+// ColumnDefinition represents definition of a single column. For computed
+// columns Type is nil and Computed contains expression after AS keyword.
+// Options are kept in the order of occurrence.
+//
+//	<column_definition> ::=
+//	column_name <data_type>
+//	    [ FILESTREAM ]
+//	    [ COLLATE collation_name ]
+//	    [ SPARSE ]
+//	    [ MASKED WITH ( FUNCTION = ' mask_function ') ]
+//	    [ [ CONSTRAINT constraint_name ] DEFAULT constant_expression ]
+//	    [ IDENTITY [ ( seed , increment ) ]
+//	    [ NOT FOR REPLICATION ]
+//	    [ GENERATED ALWAYS AS ROW { START | END } [ HIDDEN ] ]
+//	    [ NULL | NOT NULL ]
+//	    [ ROWGUIDCOL ]
+//	    [ <column_constraint> [, ...n ] ]
+//
+//	<computed_column_definition> ::=
+//	column_name AS computed_column_expression
+//	[ PERSISTED [ NOT NULL ] ]
+//	[ <column_constraint> ]
+type ColumnDefinition struct {
+	Name     string
+	Type     *DataType
+	Computed Expression
+	Options  []*ColumnOption
+	Last     token.Position
+}
+
+// ColumnOption represents single option of column definition. For
+// CONSTRAINTOPTION field Constraint is set, for other kinds Words contains
+// all words of the option, like "NOT NULL" or "IDENTITY(1, 1)".
+type ColumnOption struct {
+	Kind       ColumnOptionKind
+	Words      Expression
+	Constraint *Constraint
+}
+
+// ColumnOptionKind is an enum for kinds of column options.
+type ColumnOptionKind int
+
+const (
+	NULLOPTION ColumnOptionKind = iota
+	NOTNULLOPTION
+	IDENTITYOPTION
+	COLLATEOPTION
+	CONSTRAINTOPTION
+	OTHEROPTION
+)
+
+// Constraint represents column or table constraint, also DEFAULT constraint.
+//
+//	[ CONSTRAINT constraint_name ]
+//	{ { PRIMARY KEY | UNIQUE } [ CLUSTERED | NONCLUSTERED ]
+//	        [ ( column [ ASC | DESC ] [ ,...n ] ) ]
+//	        [ WITH FILLFACTOR = fillfactor | WITH ( <index_option> [ ,...n ] ) ]
+//	        [ ON { partition_scheme_name ( partition_column_name )
+//	            | filegroup | "default" } ]
+//	    | [ FOREIGN KEY ] [ ( column [ ,...n ] ) ]
+//	        REFERENCES referenced_table_name [ ( ref_column [ ,...n ] ) ]
+//	        [ ON DELETE { NO ACTION | CASCADE | SET NULL | SET DEFAULT } ]
+//	        [ ON UPDATE { NO ACTION | CASCADE | SET NULL | SET DEFAULT } ]
+//	        [ NOT FOR REPLICATION ]
+//	    | CHECK [ NOT FOR REPLICATION ] ( logical_expression )
+//	    | DEFAULT constant_expression [ FOR column ] [ WITH VALUES ]
+//	}
+//
+// Columns are key columns (with optional ASC or DESC) of PRIMARY KEY and
+// UNIQUE constraints or referencing columns of FOREIGN KEY. Expr is a
+// condition of CHECK constraint (with parentheses) or DEFAULT value.
+// NotForReplication is used only by CHECK constraint, in other constraints
+// NOT FOR REPLICATION is kept in Options together with the rest of words.
+type Constraint struct {
+	Name              string
+	Kind              ConstraintKind
+	Clustered         string
+	Columns           []Expression
+	References        string
+	RefColumns        []string
+	NotForReplication bool
+	Expr              Expression
+	Options           Expression
+	Last              token.Position
+}
+
+// ConstraintKind is an enum for kinds of constraints. REFERENCES is a
+// column-level foreign key without FOREIGN KEY keywords.
+type ConstraintKind int
+
+const (
+	PRIMARYKEY ConstraintKind = iota
+	UNIQUE
+	FOREIGNKEY
+	REFERENCES
+	CHECK
+	DEFAULT
+)
+
+// AlterTableStatement represents ALTER TABLE statement. From SQL Server 2019
+// documentation (simplified):
+//
+//	ALTER TABLE { database_name.schema_name.table_name | schema_name.table_name | table_name }
+//	{
+//	    ALTER COLUMN column_name
+//	        { type_name [ ( precision [ , scale ] | max ) ]
+//	            [ COLLATE collation_name ] [ NULL | NOT NULL ] [ SPARSE ]
+//	        | { ADD | DROP } { ROWGUIDCOL | PERSISTED | NOT FOR REPLICATION | SPARSE | HIDDEN } }
+//	    | [ WITH { CHECK | NOCHECK } ] ADD
+//	        { <column_definition> | <computed_column_definition>
+//	          | <table_constraint> | <column_set_definition> } [ ,...n ]
+//	    | DROP
+//	        { [ CONSTRAINT ] [ IF EXISTS ] constraint_name [ WITH ( <drop_clustered_constraint_option> [ ,...n ] ) ]
+//	          | COLUMN [ IF EXISTS ] column_name } [ ,...n ]
+//	    | [ WITH { CHECK | NOCHECK } ] { CHECK | NOCHECK } CONSTRAINT
+//	        { ALL | constraint_name [ ,...n ] }
+//	    | { ENABLE | DISABLE } TRIGGER { ALL | trigger_name [ ,...n ] }
+//	    | ...
+//	}
+//
+// With contains CHECK or NOCHECK (as written) from WITH CHECK or WITH NOCHECK
+// and it's empty otherwise. Depending on Action one of fields Elements
+// (ADDACTION), Column (ALTERCOLUMNACTION), Drops (DROPACTION), Constraints
+// (CHECKACTION and NOCHECKACTION) or Words (OTHERACTION) is set.
+type AlterTableStatement struct {
+	Terminator
+	Name        string
+	With        string
+	Action      AlterTableAction
+	Elements    []TableElement
+	Column      *ColumnDefinition
+	Drops       []*DropItem
+	Constraints []string
+	Words       Expression
+}
+
+// AlterTableAction is an enum for kinds of ALTER TABLE statements.
+type AlterTableAction int
+
+const (
+	ADDACTION AlterTableAction = iota
+	ALTERCOLUMNACTION
+	DROPACTION
+	CHECKACTION
+	NOCHECKACTION
+	OTHERACTION
+)
+
+// DropItem represents single item of ALTER TABLE ... DROP statement. Kind
+// contains CONSTRAINT or COLUMN keyword (as written) or it's empty when the
+// keyword is omitted. Options contains WITH clause of dropped constraint.
+type DropItem struct {
+	Kind     string
+	IfExists bool
+	Name     string
+	Options  Expression
+}
+
+// RawTableElement represents element of table definition which isn't
+// supported by the parser, like table index or PERIOD FOR SYSTEM_TIME.
+type RawTableElement struct {
+	Words Expression
+	Last  token.Position
+}
+
+func (*CreateTableStatement) statementNode() {}
+func (*AlterTableStatement) statementNode()  {}
+
+func (*ColumnDefinition) tableElement() {}
+func (*Constraint) tableElement()       {}
+func (*RawTableElement) tableElement()  {}
+
+func (c *ColumnDefinition) End() token.Position { return c.Last }
+func (c *Constraint) End() token.Position       { return c.Last }
+func (e *RawTableElement) End() token.Position  { return e.Last }
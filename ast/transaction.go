@@ -0,0 +1,82 @@
+package ast
+
+// TryCatchStatement represents TRY ... CATCH construct.
+//
+//	BEGIN TRY
+//	    { sql_statement | statement_block }
+//	END TRY
+//	BEGIN CATCH
+//	    [ { sql_statement | statement_block } ]
+//	END CATCH
+type TryCatchStatement struct {
+	Terminator
+	Try   []Statement
+	Catch []Statement
+}
+
+// TransactionStatement represents BEGIN, COMMIT, ROLLBACK and SAVE
+// TRANSACTION statements. From SQL Server 2019 documentation:
+//
+//	BEGIN [ DISTRIBUTED ] { TRAN | TRANSACTION }
+//	    [ { transaction_name | @tran_name_variable }
+//	      [ WITH MARK [ 'description' ] ] ]
+//	COMMIT [ { TRAN | TRANSACTION } [ transaction_name | @tran_name_variable ] ]
+//	    [ WITH ( DELAYED_DURABILITY = { OFF | ON } ) ]
+//	ROLLBACK { TRAN | TRANSACTION }
+//	    [ transaction_name | @tran_name_variable
+//	    | savepoint_name | @savepoint_variable ]
+//	SAVE { TRAN | TRANSACTION } { savepoint_name | @savepoint_variable }
+//	{ COMMIT | ROLLBACK } [ WORK ]
+//
+// Keyword contains TRAN, TRANSACTION or WORK (as written) and it's empty when
+// the keyword is omitted. Description is a string literal after WITH MARK and
+// Options contains WITH clause of COMMIT.
+type TransactionStatement struct {
+	Terminator
+	Kind        TransactionKind
+	Distributed bool
+	Keyword     string
+	Name        string
+	Mark        bool
+	Description string
+	Options     Expression
+}
+
+// TransactionKind is an enum for kinds of transaction statements.
+type TransactionKind int
+
+const (
+	BEGINTRAN TransactionKind = iota
+	COMMITTRAN
+	ROLLBACKTRAN
+	SAVETRAN
+)
+
+// ThrowStatement represents THROW statement. Args are empty when THROW is
+// used without arguments to re-throw the error in CATCH block.
+//
+//	THROW [ { error_number | @local_variable },
+//	        { message | @local_variable },
+//	        { state | @local_variable } ]
+type ThrowStatement struct {
+	Terminator
+	Args []Expression
+}
+
+// RaiserrorStatement represents RAISERROR statement. Options contains words
+// of WITH clause (LOG, NOWAIT or SETERROR).
+//
+//	RAISERROR ( { msg_id | msg_str | @local_variable }
+//	    { , severity , state }
+//	    [ , argument [ , ...n ] ] )
+//	    [ WITH option [ , ...n ] ]
+type RaiserrorStatement struct {
+	Terminator
+	Args    []Expression
+	Options []string
+}
+
+func (*TryCatchStatement) statementNode()    {}
+func (*TransactionStatement) statementNode() {}
+func (*ThrowStatement) statementNode()       {}
+func (*RaiserrorStatement) statementNode()   {}
@@ -0,0 +1,40 @@
+package ast
+
+// TriggerStatement represents CREATE or ALTER TRIGGER statement for DML, DDL
+// and logon triggers. From SQL Server 2019 documentation:
+//
+//	CREATE [ OR ALTER ] TRIGGER [ schema_name . ]trigger_name
+//	ON { table | view | DATABASE | ALL SERVER }
+//	[ WITH <trigger_option> [ ,...n ] ]
+//	{ FOR | AFTER | INSTEAD OF }
+//	{ [ INSERT ] [ , ] [ UPDATE ] [ , ] [ DELETE ] | event_type [ ,...n ] }
+//	[ WITH APPEND ]
+//	[ NOT FOR REPLICATION ]
+//	AS { sql_statement [ ; ] [ ,...n ] | EXTERNAL NAME <method specifier> }
+//
+// Target is empty when AllServer is true. Body contains all statements after
+// AS keyword till the end of the batch.
+type TriggerStatement struct {
+	Terminator
+	Mode              CreateMode
+	Name              string
+	Target            string
+	AllServer         bool
+	Options           []Expression
+	Timing            TriggerTiming
+	Events            []Word
+	Append            bool
+	NotForReplication bool
+	Body              []Statement
+}
+
+// TriggerTiming is an enum for moments when trigger is fired.
+type TriggerTiming int
+
+const (
+	FOR TriggerTiming = iota
+	AFTER
+	INSTEADOF
+)
+
+func (*TriggerStatement) statementNode() {}
@@ -0,0 +1,46 @@
+package ast
+
+import "mssfmt/token"
+
+// UpdateStatement represents UPDATE statement. From SQL Server 2019
+// documentation (simplified):
+//
+//	UPDATE
+//	    [ TOP ( expression ) [ PERCENT ] ]
+//	    { table_or_view_name | @table_variable } [ WITH ( <table_hint> ) ]
+//	    SET
+//	        { column_name = { expression | DEFAULT | NULL }
+//	          | column_name { += | -= | *= | /= | %= | &= | ^= | |= } expression
+//	          | @variable = expression
+//	          | @variable = column = expression
+//	          | udt_column_name.method_name ( argument [ ,...n ] )
+//	        } [ ,...n ]
+//	    [ <OUTPUT Clause> ]
+//	    [ FROM { <table_source> } [ ,...n ] ]
+//	    [ WHERE { <search_condition> | CURRENT OF cursor_name } ]
+//	    [ OPTION ( <query_hint> [ ,...n ] ) ]
+//
+// Target contains words between UPDATE and SET, including TOP clause and
+// table hints. Output contains words of OUTPUT clause after OUTPUT keyword.
+type UpdateStatement struct {
+	Terminator
+	Target      Expression
+	Assignments []*Assignment
+	Output      Expression
+	From        *FromClause
+	Where       *WhereClause
+	Options     *SelectOptions
+}
+
+// Assignment represents single item of SET clause of UPDATE statement.
+// Operator is "=" or compound assignment operator like "+=". Items without
+// operator (like calls of methods) are kept in Target and their Operator is
+// empty. End is position of the last word of the item.
+type Assignment struct {
+	Target   Expression
+	Operator string
+	Value    Expression
+	End      token.Position
+}
+
+func (*UpdateStatement) statementNode() {}
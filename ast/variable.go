@@ -0,0 +1,55 @@
+package ast
+
+import "mssfmt/token"
+
+// DeclareStatement represents DECLARE statement of local variables. Cursor
+// declarations (DECLARE name CURSOR) aren't part of this statement.
+//
+//	DECLARE
+//	{
+//	    { @local_variable [AS] data_type } [ = value ]
+//	  | { @cursor_variable_name CURSOR }
+//	  | { @table_variable_name [AS] <table_type_definition> }
+//	} [ ,...n ]
+type DeclareStatement struct {
+	Terminator
+	Variables []*VariableDeclaration
+}
+
+// VariableDeclaration represents declaration of a single variable. For table
+// variables Type is nil and Table contains definition of columns. Cursor
+// variables have data type CURSOR. End is position of the last word of the
+// declaration.
+type VariableDeclaration struct {
+	Name      string
+	ASKeyword bool
+	Type      *DataType
+	Table     *TableDefinition
+	Value     Expression
+	End       token.Position
+}
+
+// SetStatement represents assignment of a value to a local variable. Operator
+// is "=" or one of compound assignment operators like "+=".
+//
+//	SET @local_variable { = | += | -= | *= | /= | %= | &= | ^= | |= } expression
+type SetStatement struct {
+	Terminator
+	Variable string
+	Operator string
+	Value    Expression
+}
+
+// SetOptionStatement represents SET statement which changes session options,
+// like SET NOCOUNT ON or SET TRANSACTION ISOLATION LEVEL READ COMMITTED.
+// Options are comma-separated names of options and Value contains the rest of
+// the statement.
+type SetOptionStatement struct {
+	Terminator
+	Options []string
+	Value   Expression
+}
+
+func (*DeclareStatement) statementNode()   {}
+func (*SetStatement) statementNode()       {}
+func (*SetOptionStatement) statementNode() {}
@@ -0,0 +1,29 @@
+package ast
+
+// ViewStatement represents CREATE or ALTER VIEW statement.
+// From SQL Server 2019 documentation:
+//
+//	CREATE [ OR ALTER ] VIEW [ schema_name . ] view_name [ (column [ ,...n ] ) ]
+//	[ WITH <view_attribute> [ ,...n ] ]
+//	AS select_statement
+//	[ WITH CHECK OPTION ]
+//
+//	<view_attribute> ::=
+//	{
+//	    [ ENCRYPTION ]
+//	    [ SCHEMABINDING ]
+//	    [ VIEW_METADATA ]
+//	}
+//
+// Query is nil when view body couldn't be parsed as SELECT statement.
+type ViewStatement struct {
+	Terminator
+	Mode        CreateMode
+	Name        string
+	Columns     []string
+	Options     []Expression
+	Query       *SelectStatement
+	CheckOption bool
+}
+
+func (*ViewStatement) statementNode() {}
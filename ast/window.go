@@ -0,0 +1,38 @@
+package ast
+
+import "mssfmt/token"
+
+// WindowSpec represents window specification - content of parentheses after
+// OVER keyword or in WINDOW clause. From SQL Server 2022 documentation:
+//
+//	OVER (
+//	    [ <ORDER BY clause> ]
+//	    [ <ROW or RANGE clause> ]
+//	    [ <PARTITION BY clause> ]
+//	    [ <existing_window_name> ]
+//	)
+//
+//	<ROW or RANGE clause> ::=
+//	{ ROWS | RANGE } <window frame extent>
+//
+// Name is a name of existing window which is extended by this specification.
+// PartitionBy and OrderBy contain comma-separated items of these clauses
+// and Frame contains all words of ROWS or RANGE clause. End is position of
+// the closing parenthesis. Specification of OVER clause is kept in OVER
+// keyword of flat expression.
+type WindowSpec struct {
+	Name        string
+	PartitionBy []Expression
+	OrderBy     []Expression
+	Frame       Expression
+	End         token.Position
+}
+
+// WindowDefinition represents single named window of WINDOW clause in SELECT
+// query.
+//
+//	WINDOW window_name AS ( <window_spec> ) [ ,...n ]
+type WindowDefinition struct {
+	Name string
+	Spec *WindowSpec
+}
@@ -0,0 +1,99 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mssfmt/printer"
+	"os"
+	"path/filepath"
+)
+
+// Config describes formatting style of the printer together with changes
+// made by the formatter before printing. RewriteAliases makes the formatter
+// rewrite aliases of columns given as "alias = expression" or by string
+// literals to "expression AS alias".
+type Config struct {
+	printer.Config
+	RewriteAliases bool `json:"rewriteAliases"`
+}
+
+// DefaultConfig is the style used by Source, printer.DefaultConfig without
+// any changes made before printing.
+var DefaultConfig = Config{
+	Config:         printer.DefaultConfig,
+	RewriteAliases: false,
+}
+
+// ConfigFile is the name of the file with formatting style. It's searched for
+// in the directory of the formatted script and then in its parent
+// directories.
+const ConfigFile = ".mssfmt.json"
+
+// Function FindConfig returns path of the configuration file which applies to
+// the script at given path. Empty string is returned if there is no such file.
+func FindConfig(path string) (string, error) {
+	dir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return "", err
+	}
+	for {
+		name := filepath.Join(dir, ConfigFile)
+		if info, err := os.Stat(name); err == nil && !info.IsDir() {
+			return name, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// Function LoadConfig returns formatting style of the script at given path.
+// Options of the configuration file found by FindConfig override
+// DefaultConfig, options missing in the file keep default values.
+func LoadConfig(path string) (Config, error) {
+	cfg := DefaultConfig
+	name, err := FindConfig(path)
+	if err != nil || name == "" {
+		return cfg, err
+	}
+	content, err := ioutil.ReadFile(name)
+	if err != nil {
+		return cfg, err
+	}
+	if cfg, err = ParseConfig(content); err != nil {
+		return cfg, fmt.Errorf("%s: %s", name, err)
+	}
+	return cfg, nil
+}
+
+// Function ParseConfig parses content of the configuration file. Unknown
+// options and invalid values are reported as errors.
+func ParseConfig(content []byte) (Config, error) {
+	cfg := DefaultConfig
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&cfg); err != nil {
+		return DefaultConfig, err
+	}
+	return cfg, CheckConfig(cfg)
+}
+
+// Function CheckConfig reports invalid values of formatting options.
+func CheckConfig(cfg Config) error {
+	switch {
+	case cfg.IndentWidth < 1:
+		return fmt.Errorf("indentWidth has to be positive, got: %d",
+			cfg.IndentWidth)
+	case cfg.LineWidth < 1:
+		return fmt.Errorf("lineWidth has to be positive, got: %d",
+			cfg.LineWidth)
+	case cfg.AlignThreshold < 0:
+		return fmt.Errorf("alignThreshold cannot be negative, got: %d",
+			cfg.AlignThreshold)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package format
+
+import (
+	"io/ioutil"
+	"mssfmt/printer"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Test for finding configuration file in parent directories of the script.
+func TestLoadConfig(t *testing.T) {
+	root, err := ioutil.TempDir("", "mssfmt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	dir := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	script := filepath.Join(dir, "script.sql")
+
+	cfg, err := LoadConfig(script)
+	if err != nil || cfg != DefaultConfig {
+		t.Errorf("Expected default config, got: %+v, %v", cfg, err)
+	}
+
+	content := []byte(`{"indentWidth": 2, "useTabs": true,
+		"rewriteAliases": true}`)
+	name := filepath.Join(root, "a", ConfigFile)
+	if err := ioutil.WriteFile(name, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if found, err := FindConfig(script); err != nil || found != name {
+		t.Errorf("Expected config %s, got: %s, %v", name, found, err)
+	}
+	cfg, err = LoadConfig(script)
+	exp := DefaultConfig
+	exp.IndentWidth, exp.UseTabs, exp.RewriteAliases = 2, true, true
+	if err != nil || cfg != exp {
+		t.Errorf("Expected config %+v, got: %+v, %v", exp, cfg, err)
+	}
+}
+
+// Test for reporting invalid configuration files.
+func TestParseConfig(t *testing.T) {
+	invalid := []string{
+		`{"indentWidth": 0}`,
+		`{"lineWidth": -1}`,
+		`{"alignThreshold": -1}`,
+		`{"indent": 2}`,
+		`{"useTabs": "yes"}`,
+		`{"keywordCase": "title"}`,
+		`{"typeCase": 1}`,
+		`{"aliasKeyword": "sometimes"}`,
+		`{`,
+	}
+	for _, content := range invalid {
+		if _, err := ParseConfig([]byte(content)); err == nil {
+			t.Errorf("Expected error for %s, got nil", content)
+		}
+	}
+	cfg, err := ParseConfig([]byte(`{"lineWidth": 100, "keywordCase": "Lower"}`))
+	if err != nil || cfg.LineWidth != 100 || cfg.IndentWidth != 4 ||
+		cfg.KeywordCase != printer.LOWER {
+		t.Errorf("Expected lineWidth 100, lower keywords and default "+
+			"indentWidth, got: %+v, %v", cfg, err)
+	}
+}
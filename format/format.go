@@ -0,0 +1,443 @@
+// Package format implements formatting of T-SQL scripts together with
+// verification that formatting doesn't change meaning of the script. This
+// package is based on Go package "format".
+package format
+
+import (
+	"bytes"
+	"fmt"
+	"mssfmt/ast"
+	"mssfmt/parser"
+	"mssfmt/printer"
+	"mssfmt/scanner"
+	"mssfmt/token"
+	"strings"
+)
+
+// MismatchError is returned when formatted script isn't equivalent to the
+// source. Pos is position of the first divergent word in the source and
+// OutPos is position of the corresponding word in formatted script. Expected
+// and Found are normalized literals of these words, empty at the end of the
+// script.
+type MismatchError struct {
+	Pos      token.Position
+	OutPos   token.Position
+	Expected string
+	Found    string
+}
+
+// Error returns description of the first difference between the source and
+// formatted script.
+func (e *MismatchError) Error() string {
+	expected, found := e.Expected, e.Found
+	if expected == "" {
+		expected = "end of script"
+	}
+	if found == "" {
+		found = "end of script"
+	}
+	return fmt.Sprintf("%s: formatting changes the script: expected %s, "+
+		"found %s at %s", e.Pos, expected, found, e.OutPos)
+}
+
+// Function Source formats given T-SQL script in the default style. Formatted
+// script is verified by Verify function and in case of any difference only
+// the error is returned.
+func Source(name string, src []byte) ([]byte, error) {
+	return SourceConfig(&DefaultConfig, name, src)
+}
+
+// Function SourceConfig formats given T-SQL script in the style described by
+// cfg. Aliases of columns are rewritten before printing when RewriteAliases
+// is set. Formatted script is verified as in Source, but rewritten aliases
+// together with "=" or AS next to them may be moved after expressions of
+// their columns.
+func SourceConfig(cfg *Config, name string, src []byte) ([]byte, error) {
+	words := scanWords(name, src)
+	var p parser.Parser
+	p.Init(name, words)
+
+	script := p.Script()
+	var edits []edit
+	if cfg.RewriteAliases {
+		edits = rewriteAliases(script)
+	}
+
+	var out bytes.Buffer
+	if err := cfg.Fprint(&out, script); err != nil {
+		return nil, err
+	}
+	err := verify(&cfg.Config, semicolonsOf(&cfg.Config, script), edits,
+		script.Aliases, words, scanWords(name, out.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// Function Verify checks if formatted script is equivalent to the source.
+// Both scripts are scanned and their words (except comments) are compared
+// one by one: tokens have to be the same and literals have to be the same
+// after normalization, so only case of keywords and spaces inside multi-word
+// keywords may differ. Comments may be moved, but each comment of the source
+// has to be present in formatted script. Error *MismatchError describes the
+// first difference.
+func Verify(name string, src, formatted []byte) error {
+	return VerifyConfig(&printer.DefaultConfig, name, src, formatted)
+}
+
+// Function VerifyConfig checks if script formatted in the style described by
+// cfg is equivalent to the source. It works like Verify, but optional words
+// which the style adds or removes (AS of aliases, INNER and OUTER of joins
+// and semicolons after statements recognized by the parser) may be missing
+// in either script.
+func VerifyConfig(cfg *printer.Config, name string, src,
+	formatted []byte) error {
+	words := scanWords(name, src)
+	var p parser.Parser
+	p.Init(name, words)
+	script := p.Script()
+	return verify(cfg, semicolonsOf(cfg, script), nil, script.Aliases, words,
+		scanWords(name, formatted))
+}
+
+// Type semicolons describes semicolons which the printer may add or remove.
+// Keys of ends are offsets of the last words of statements after which
+// semicolons are added and keys of empty are offsets of standalone semicolons
+// which are omitted. Keys of starts are offsets of the first words of all
+// other statements. Formatted script with added semicolons has to start its
+// statements at the same words.
+type semicolons struct {
+	ends   map[int]bool
+	empty  map[int]bool
+	starts map[int]bool
+}
+
+// Function semicolonsOf returns semicolons which may be added to or removed
+// from the script when missing semicolons are added in the style described
+// by cfg.
+func semicolonsOf(cfg *printer.Config, script *ast.Script) semicolons {
+	bounds := semicolons{ends: map[int]bool{}, empty: map[int]bool{}}
+	if !cfg.Semicolons {
+		return bounds
+	}
+	bounds.starts = statementStarts(script)
+	for _, batch := range script.Batches {
+		walkStatements(batch.Statements, func(stmts []ast.Statement) {
+			for id, stmt := range stmts {
+				_, isEmpty := stmt.(*ast.EmptyStatement)
+				switch {
+				case isEmpty && id > 0 && printer.Terminable(stmts[id-1]) &&
+					len(script.Comments[stmt]) == 0:
+					bounds.empty[stmt.Pos().Offset] = true
+				case !stmt.Terminated() && printer.Terminable(stmt):
+					bounds.ends[stmt.End().Offset] = true
+				}
+			}
+		})
+	}
+	return bounds
+}
+
+// Function statementStarts returns offsets of the first words of all
+// statements of the script except standalone semicolons.
+func statementStarts(script *ast.Script) map[int]bool {
+	starts := make(map[int]bool)
+	for _, batch := range script.Batches {
+		walkStatements(batch.Statements, func(stmts []ast.Statement) {
+			for _, stmt := range stmts {
+				if _, isEmpty := stmt.(*ast.EmptyStatement); !isEmpty {
+					starts[stmt.Pos().Offset] = true
+				}
+			}
+		})
+	}
+	return starts
+}
+
+// Function sameStatements checks if formatted script, parsed again, starts
+// its statements at the same words as the source. Keys of matched are
+// offsets of formatted words and values are offsets of corresponding source
+// words. It returns offset of the first source word at which statements
+// differ or -1 when they are the same.
+func sameStatements(bounds semicolons, matched map[int]int,
+	formatted parser.Words) int {
+	var p parser.Parser
+	p.Init("", formatted)
+	first := -1
+	diverge := func(offset int) {
+		if first < 0 || offset < first {
+			first = offset
+		}
+	}
+
+	starts := make(map[int]bool, len(bounds.starts))
+	for offset := range statementStarts(p.Script()) {
+		src, ok := matched[offset]
+		if !ok || !bounds.starts[src] {
+			diverge(src)
+			continue
+		}
+		starts[src] = true
+	}
+	for offset := range bounds.starts {
+		if !starts[offset] {
+			diverge(offset)
+		}
+	}
+	return first
+}
+
+// Function walkStatements calls visit for the list of statements and for
+// all lists of statements nested in them, like statements of blocks or
+// bodies of procedures. Single statements nested in IF and WHILE are visited
+// as one-element lists.
+func walkStatements(stmts []ast.Statement, visit func([]ast.Statement)) {
+	visit(stmts)
+	for _, stmt := range stmts {
+		switch s := stmt.(type) {
+		case *ast.BlockStatement:
+			walkStatements(s.Statements, visit)
+		case *ast.IfStatement:
+			walkStatements([]ast.Statement{s.Then}, visit)
+			if s.Else != nil {
+				walkStatements([]ast.Statement{s.Else}, visit)
+			}
+		case *ast.WhileStatement:
+			walkStatements([]ast.Statement{s.Body}, visit)
+		case *ast.TryCatchStatement:
+			walkStatements(s.Try, visit)
+			walkStatements(s.Catch, visit)
+		case *ast.ProcedureStatement:
+			walkStatements(s.Body, visit)
+		case *ast.FunctionStatement:
+			walkStatements(s.Body, visit)
+		case *ast.TriggerStatement:
+			walkStatements(s.Body, visit)
+		}
+	}
+}
+
+// Function verify compares words of the source and formatted script.
+// Semicolons may be added only after the last words of statements described
+// by bounds and only when formatted script is parsed into statements which
+// start at the same words. AS keyword may be added or removed only before
+// aliases of the source recorded by the parser. Words removed by edits are
+// skipped in the source and after the last word of each edited expression an
+// alias with the same name as the alias in the source, optionally preceded by
+// AS, is expected.
+func verify(cfg *printer.Config, bounds semicolons, edits []edit,
+	aliasPos []token.Position, src, formatted parser.Words) error {
+	srcWords, srcComments := splitComments(src)
+	outWords, outComments := splitComments(formatted)
+	isAlias := make(map[int]bool, len(aliasPos))
+	for _, pos := range aliasPos {
+		isAlias[pos.Offset] = true
+	}
+	removed := make(map[int]bool)
+	aliases := make(map[int]ast.Word, len(edits))
+	for _, e := range edits {
+		for _, offset := range e.removed {
+			removed[offset] = true
+		}
+		aliases[e.end] = src[e.alias]
+	}
+
+	matched := false
+	offsets := make(map[int]int, len(outWords))
+	added := make([]MismatchError, 0, len(bounds.ends))
+	for srcId, outId := 0, 0; srcId < len(srcWords) || outId < len(outWords); {
+		err := MismatchError{}
+		if srcId < len(srcWords) {
+			err.Pos = srcWords[srcId].Pos
+			err.Expected = normalizeAt(srcWords, srcId)
+		} else {
+			err.Pos = endPos(src)
+		}
+		if outId < len(outWords) {
+			err.OutPos = outWords[outId].Pos
+			err.Found = normalizeAt(outWords, outId)
+		} else {
+			err.OutPos = endPos(formatted)
+		}
+
+		switch {
+		case srcId < len(srcWords) && removed[srcWords[srcId].Pos.Offset]:
+			srcId++
+		case srcId < len(srcWords) && outId < len(outWords) &&
+			srcWords[srcId].Token == outWords[outId].Token &&
+			err.Expected == err.Found:
+			if matched && separated(srcWords[srcId-1], srcWords[srcId],
+				outWords[outId-1], outWords[outId]) {
+				err.Pos = srcWords[srcId-1].Pos
+				err.OutPos = outWords[outId-1].Pos
+				err.Expected = srcWords[srcId-1].Literal +
+					srcWords[srcId].Literal
+				err.Found = outWords[outId-1].Literal + " " +
+					outWords[outId].Literal
+				return &err
+			}
+			offsets[outWords[outId].Pos.Offset] = srcWords[srcId].Pos.Offset
+			srcId++
+			outId++
+			matched = true
+			if alias, ok := aliases[srcWords[srcId-1].Pos.Offset]; ok {
+				if outId < len(outWords) && outWords[outId].Token == token.AS {
+					outId++
+				}
+				if outId >= len(outWords) || outWords[outId].Token != token.IDENT ||
+					aliasName(outWords[outId]) != aliasName(alias) {
+					err.Pos, err.Expected = alias.Pos, alias.Literal
+					err.OutPos, err.Found = endPos(formatted), ""
+					if outId < len(outWords) {
+						err.OutPos = outWords[outId].Pos
+						err.Found = normalizeAt(outWords, outId)
+					}
+					return &err
+				}
+				outId++
+				matched = false
+			}
+			continue
+		case srcId < len(srcWords) && (isOptional(cfg, srcWords, srcId,
+			srcId+1 < len(srcWords) && isAlias[srcWords[srcId+1].Pos.Offset]) ||
+			srcWords[srcId].Token == token.SEMICOLON &&
+				bounds.empty[srcWords[srcId].Pos.Offset]):
+			srcId++
+		case outId < len(outWords) && isOptional(cfg, outWords, outId,
+			srcId < len(srcWords) && isAlias[srcWords[srcId].Pos.Offset]):
+			outId++
+		case outId < len(outWords) &&
+			outWords[outId].Token == token.SEMICOLON && srcId > 0 &&
+			bounds.ends[srcWords[srcId-1].Pos.Offset]:
+			added = append(added, err)
+			outId++
+		default:
+			return &err
+		}
+		matched = false
+	}
+
+	if len(added) > 0 {
+		if offset := sameStatements(bounds, offsets, formatted); offset >= 0 {
+			err := added[0]
+			for _, e := range added[1:] {
+				if e.Pos.Offset <= offset {
+					err = e
+				}
+			}
+			return &err
+		}
+	}
+
+	found := make(map[string]int, len(outComments))
+	for _, comment := range outComments {
+		found[normalize(comment)]++
+	}
+	for _, comment := range srcComments {
+		text := normalize(comment)
+		if found[text] == 0 {
+			return &MismatchError{Pos: comment.Pos, OutPos: endPos(formatted),
+				Expected: text}
+		}
+		found[text]--
+	}
+	return nil
+}
+
+// Function separated checks if words srcPrev and srcCurr, which touch each
+// other in the source and can't be separated (see token.Inseparable), are
+// printed as outPrev and outCurr with whitespace between them.
+func separated(srcPrev, srcCurr, outPrev, outCurr ast.Word) bool {
+	return srcPrev.Touches(srcCurr) &&
+		token.Inseparable(srcPrev.Token, srcCurr.Token) &&
+		!outPrev.Touches(outCurr)
+}
+
+// Function isOptional checks if words[id] is an optional word which may be
+// added or removed in the style described by cfg. OUTER after LEFT, RIGHT or
+// FULL, INNER before JOIN and AS before an alias (alias is true when the
+// word following AS is an alias recorded by the parser) are optional when
+// the style changes them.
+func isOptional(cfg *printer.Config, words parser.Words, id int,
+	alias bool) bool {
+	prev, next := ast.Word{}, ast.Word{}
+	if id > 0 {
+		prev = words[id-1]
+	}
+	if id+1 < len(words) {
+		next = words[id+1]
+	}
+
+	switch words[id].Token {
+	case token.OUTER:
+		return cfg.OuterKeyword != printer.KEEP && (prev.Token == token.LEFT ||
+			prev.Token == token.RIGHT || prev.Token == token.FULL)
+	case token.INNER:
+		return cfg.InnerKeyword != printer.KEEP && next.Token == token.JOIN
+	case token.AS:
+		return cfg.AliasKeyword != printer.KEEP && alias
+	}
+	return false
+}
+
+// Function splitComments separates comments from other words.
+func splitComments(words parser.Words) (parser.Words, parser.Words) {
+	other := make(parser.Words, 0, len(words))
+	comments := make(parser.Words, 0, 8)
+	for _, word := range words {
+		if word.Token == token.COMMENT {
+			comments = append(comments, word)
+		} else {
+			other = append(other, word)
+		}
+	}
+	return other, comments
+}
+
+// Function normalize returns literal of the word in the form used for
+// comparison. Keywords are represented by their token. Identifiers which
+// are keywords without their own token, names of built-in functions or names
+// of data types (see printer.IsKeyword) are compared case-insensitively,
+// because the printer changes their case. Other identifiers have to be the
+// same. Trailing whitespace of comments is ignored.
+func normalize(word ast.Word) string {
+	switch {
+	case word.Token.IsKeyword():
+		return word.Token.String()
+	case word.Token == token.COMMENT:
+		return strings.TrimRight(word.Literal, " \t\r")
+	case word.Token == token.IDENT && printer.IsKeyword(word.Literal):
+		return strings.ToUpper(word.Literal)
+	}
+	return word.Literal
+}
+
+// Function normalizeAt returns words[id] in the form used for comparison
+// (see normalize). Keywords used as names (see printer.IsKeywordAt), like
+// column Count, have to be the same.
+func normalizeAt(words parser.Words, id int) string {
+	if words[id].Token.IsKeyword() &&
+		!printer.IsKeywordAt(ast.Expression(words), id) {
+		return words[id].Literal
+	}
+	return normalize(words[id])
+}
+
+// Function endPos returns position after the last word of the script.
+func endPos(words parser.Words) token.Position {
+	if len(words) == 0 {
+		return token.Position{}
+	}
+	pos := words[len(words)-1].Pos
+	pos.Column += len([]rune(words[len(words)-1].Literal))
+	pos.Offset++
+	return pos
+}
+
+// Function scanWords scans all words of the script.
+func scanWords(name string, src []byte) parser.Words {
+	var s scanner.Scanner
+	s.Init(name, src)
+	return parser.ScanWords(s)
+}
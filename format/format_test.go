@@ -0,0 +1,361 @@
+package format
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"mssfmt/parser"
+	"mssfmt/printer"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update .golden files")
+
+// Function goldenFiles returns pairs of input scripts and expected results
+// from testdata directory.
+func goldenFiles(t *testing.T) map[string]string {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "*.sql"))
+	if err != nil || len(inputs) == 0 {
+		t.Fatalf("Expected test scripts in testdata, got: %v", err)
+	}
+	files := make(map[string]string, len(inputs))
+	for _, input := range inputs {
+		files[input] = strings.TrimSuffix(input, ".sql") + ".golden"
+	}
+	return files
+}
+
+// Test for formatting scripts from testdata directory. Formatted scripts are
+// compared with .golden files, which are rewritten with -update flag.
+func TestGolden(t *testing.T) {
+	for input, golden := range goldenFiles(t) {
+		src, err := ioutil.ReadFile(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := Source(input, src)
+		if err != nil {
+			t.Errorf("Expected no error for %s, got: %v", input, err)
+			continue
+		}
+
+		if *update {
+			if err := ioutil.WriteFile(golden, out, 0644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		exp, err := ioutil.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(out, exp) {
+			t.Errorf("Expected %s to be formatted as %s, got:\n%s", input,
+				golden, out)
+		}
+	}
+}
+
+// Test for idempotency of formatting - formatting of already formatted
+// script doesn't change it.
+func TestIdempotent(t *testing.T) {
+	for _, golden := range goldenFiles(t) {
+		src, err := ioutil.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := Source(golden, src)
+		if err != nil {
+			t.Errorf("Expected no error for %s, got: %v", golden, err)
+			continue
+		}
+		if !bytes.Equal(out, src) {
+			t.Errorf("Expected %s to be unchanged, got:\n%s", golden, out)
+		}
+	}
+}
+
+// Test for formatting script with verification of the result.
+func TestSource(t *testing.T) {
+	src := "select a,b from t -- all\nwhere x=1\ngo\nexec p @a=1"
+	exp := "SELECT\n    a,\n    b\nFROM t -- all\nWHERE x = 1\nGO\n\nEXEC p @a = 1\n"
+
+	out, err := Source("test.sql", []byte(src))
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+	if string(out) != exp {
+		t.Errorf("Expected formatted script:\n%s\ngot:\n%s", exp, out)
+	}
+}
+
+// Test for verification of equivalent and changed scripts.
+func TestVerify(t *testing.T) {
+	src := "select a, [B] from t /* c */ where x = 'a'"
+
+	equivalent := []string{
+		"SELECT a,\n    [B]\nFROM t\nWHERE x = 'a' /* c */",
+		"select /* c */ a, [B] from t where x = 'a'",
+	}
+	for _, formatted := range equivalent {
+		if err := Verify("test.sql", []byte(src), []byte(formatted)); err != nil {
+			t.Errorf("Expected no error for %q, got: %v", formatted, err)
+		}
+	}
+
+	changed := []struct {
+		formatted string
+		pos       string
+		expected  string
+		found     string
+	}{
+		{"select a, [b] from t /* c */ where x = 'a'", "test.sql:1:11", "[B]", "[b]"},
+		{"SELECT A, [B] FROM t /* c */ WHERE x = 'a'", "test.sql:1:8", "a", "A"},
+		{"select a, [B] from t /* c */\nwhere x = 'A'", "test.sql:1:40", "'a'", "'A'"},
+		{"select a, [B] from t /* c */ where x =", "test.sql:1:40", "'a'", ""},
+		{"select a, [B] from t where x = 'a'", "test.sql:1:22", "/* c */", ""},
+		{"select a, [B] from t /* c */ where x = 'a';", "test.sql:1:43", "", ";"},
+	}
+	for _, c := range changed {
+		err := Verify("test.sql", []byte(src), []byte(c.formatted))
+		mismatch, ok := err.(*MismatchError)
+		if !ok {
+			t.Errorf("Expected *MismatchError for %q, got: %v", c.formatted, err)
+			continue
+		}
+		if mismatch.Pos.String() != c.pos || mismatch.Expected != c.expected ||
+			mismatch.Found != c.found {
+			t.Errorf("Expected mismatch at %s (%q, %q), got: %s (%q, %q)",
+				c.pos, c.expected, c.found, mismatch.Pos, mismatch.Expected,
+				mismatch.Found)
+		}
+		if !strings.HasPrefix(err.Error(), c.pos+": ") {
+			t.Errorf("Expected error starting with position %s, got: %v",
+				c.pos, err)
+		}
+	}
+}
+
+// Test for verification of keywords which are used as names of columns.
+func TestVerifyKeywordNames(t *testing.T) {
+	src := `select Count, count(*) from t with (nolock) order by Rows
+	OFFSET 1 rows create table u (Max int, b nvarchar(max))`
+
+	equivalent := `SELECT Count, COUNT(*) FROM t WITH (NOLOCK) ORDER BY Rows
+	OFFSET 1 ROWS CREATE TABLE u (Max int, b nvarchar(MAX))`
+	if err := Verify("test.sql", []byte(src), []byte(equivalent)); err != nil {
+		t.Errorf("Expected no error for %q, got: %v", equivalent, err)
+	}
+
+	changed := []string{
+		strings.Replace(equivalent, "Count,", "COUNT,", 1),
+		strings.Replace(equivalent, "BY Rows", "BY ROWS", 1),
+		strings.Replace(equivalent, "Max int", "MAX int", 1),
+	}
+	for _, formatted := range changed {
+		if err := Verify("test.sql", []byte(src), []byte(formatted)); err == nil {
+			t.Errorf("Expected error for %q", formatted)
+		}
+	}
+}
+
+// Test for verification of words which touch each other in the source.
+func TestVerifyTouchingWords(t *testing.T) {
+	src := "select @a+=1, cast(x as int)as y, $1, 0x1F"
+
+	err := Verify("test.sql", []byte(src),
+		[]byte("SELECT @a += 1, CAST(x AS INT) AS y, $1, 0x1F"))
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+
+	err = Verify("test.sql", []byte(src),
+		[]byte("SELECT @a + = 1, CAST(x AS INT) AS y, $1, 0x1F"))
+	mismatch, ok := err.(*MismatchError)
+	if !ok {
+		t.Fatalf("Expected *MismatchError, got: %v", err)
+	}
+	if mismatch.Pos.String() != "test.sql:1:10" || mismatch.Expected != "+=" ||
+		mismatch.Found != "+ =" {
+		t.Errorf("Expected mismatch at test.sql:1:10 (\"+=\", \"+ =\"), got: "+
+			"%s (%q, %q)", mismatch.Pos, mismatch.Expected, mismatch.Found)
+	}
+}
+
+// Test for verification of scripts with optional keywords added or removed.
+func TestVerifyConfig(t *testing.T) {
+	src := `select a x, cast(b as int) as y from t as t
+	left join u on u.id = t.id inner join v on 1 = 1`
+	formatted := `SELECT a AS x, CAST(b AS int) y FROM t t
+	LEFT OUTER JOIN u ON u.id = t.id JOIN v ON 1 = 1;`
+	cfg := printer.DefaultConfig
+	cfg.AliasKeyword, cfg.OuterKeyword = printer.ALWAYS, printer.ALWAYS
+	cfg.InnerKeyword, cfg.Semicolons = printer.NEVER, true
+
+	err := VerifyConfig(&cfg, "test.sql", []byte(src), []byte(formatted))
+	if err != nil {
+		t.Errorf("Expected no error, got: %v", err)
+	}
+	if Verify("test.sql", []byte(src), []byte(formatted)) == nil {
+		t.Errorf("Expected error of verification in the default style")
+	}
+
+	src = "select a x, cast(b as int) y from t t"
+	changed := "SELECT a AS x, CAST(b int) y FROM t t"
+	err = VerifyConfig(&cfg, "test.sql", []byte(src), []byte(changed))
+	if err == nil {
+		t.Errorf("Expected error for removed AS of CAST")
+	}
+
+	cfg.AliasKeyword = printer.NEVER
+	for src, changed := range map[string]string{
+		"CREATE PROCEDURE p WITH EXECUTE AS OWNER AS SELECT 1": "CREATE " +
+			"PROCEDURE p WITH EXECUTE OWNER AS SELECT 1",
+		"DECLARE @a AS int": "DECLARE @a int",
+	} {
+		err = VerifyConfig(&cfg, "test.sql", []byte(src), []byte(changed))
+		if err == nil {
+			t.Errorf("Expected error for removed AS of %s", src)
+		}
+	}
+	cfg.AliasKeyword = printer.ALWAYS
+
+	src = `ALTER INDEX ix ON t REBUILD
+	IF OBJECT_ID('t') IS NOT NULL DROP TABLE t
+	SELECT 1;; PRINT 1`
+	formatted = `ALTER INDEX ix ON t REBUILD IF OBJECT_ID('t') IS NOT NULL
+	DROP TABLE t; SELECT 1; PRINT 1`
+	err = VerifyConfig(&cfg, "test.sql", []byte(src), []byte(formatted))
+	if err != nil {
+		t.Errorf("Expected no error for semicolons after statements, got: %v",
+			err)
+	}
+	for _, changed := range []string{
+		"ALTER INDEX ix ON t REBUILD; IF OBJECT_ID('t') IS NOT NULL DROP TABLE t SELECT 1; PRINT 1",
+		"ALTER INDEX ix ON t REBUILD IF OBJECT_ID('t') IS NOT NULL; DROP TABLE t SELECT 1; PRINT 1",
+		"ALTER INDEX ix ON t REBUILD IF OBJECT_ID('t') IS NOT NULL DROP TABLE t SELECT 1; PRINT 1;",
+		"ALTER INDEX ix ON t REBUILD IF OBJECT_ID('t') IS NOT NULL DROP TABLE t SELECT 1 PRINT 1",
+	} {
+		err = VerifyConfig(&cfg, "test.sql", []byte(src), []byte(changed))
+		if err == nil {
+			t.Errorf("Expected error for semicolons of %s", changed)
+		}
+	}
+}
+
+// Test for rewriting of column aliases to the form "expression AS alias".
+func TestRewriteAliases(t *testing.T) {
+	src := `select Total = sum(x), 'Order' = count(*), y 'My Alias', z as 'it''s',
+	@v = 1, d at time zone 'UTC', 'a]b' = 1 from (select n = 1) as s
+	where exists (select m = 1, (select 'in' = 2) x from u) and
+	a in (select 'b c' = b from v)
+	select @v = max(y), @w = 2 from t`
+	expected := `SELECT
+    SUM(x)   AS Total,
+    COUNT(*) AS [Order],
+    y        AS [My Alias],
+    z        AS [it's],
+    @v = 1,
+    d at time zone 'UTC',
+    1 AS [a]]b]
+FROM (
+    SELECT 1 AS n
+) AS s
+WHERE EXISTS (SELECT 1 AS m, (SELECT 2 AS [in]) x FROM u)
+    AND a IN (SELECT b AS [b c] FROM v)
+SELECT
+    @v = MAX(y),
+    @w = 2
+FROM t
+`
+	cfg := DefaultConfig
+	cfg.RewriteAliases = true
+	out, err := SourceConfig(&cfg, "test.sql", []byte(src))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if string(out) != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, out)
+	}
+
+	out, err = Source("test.sql", []byte(src))
+	if err != nil || !strings.Contains(string(out), "Total = SUM(x)") {
+		t.Errorf("Expected aliases unchanged by default, got: %s, %v", out, err)
+	}
+
+	src = "select case when exists (select n = 1) then 1 when b = 1 then 2 end"
+	out, err = SourceConfig(&cfg, "test.sql", []byte(src))
+	if err != nil || !strings.Contains(string(out), "(SELECT 1 AS n)") {
+		t.Errorf("Expected alias rewritten in CASE, got: %s, %v", out, err)
+	}
+}
+
+// Test for verification of rewritten aliases against the source. Only aliases
+// and their "=" or AS may be moved.
+func TestVerifyRewrite(t *testing.T) {
+	src := "select Total = sum(x), y 'a]b' from t"
+	words := scanWords("test.sql", []byte(src))
+	var p parser.Parser
+	p.Init("test.sql", words)
+	edits := rewriteAliases(p.Script())
+
+	tests := []struct {
+		formatted string
+		valid     bool
+	}{
+		{"SELECT SUM(x) AS Total, y AS [a]]b] FROM t", true},
+		{"SELECT SUM(x) Total, y [a]]b] FROM t", true},
+		{"SELECT SUM(x) AS Totals, y AS [a]]b] FROM t", false},
+		{"SELECT SUM(x) AS Total, y AS [a]b] FROM t", false},
+		{"SELECT SUM(x) AS Total, y AS [a]]b], 1 FROM t", false},
+		{"SELECT SUM(y) AS Total, y AS [a]]b] FROM t", false},
+		{"SELECT Total = SUM(x), y AS [a]]b] FROM t", false},
+	}
+	for _, test := range tests {
+		err := verify(&printer.DefaultConfig, semicolons{}, edits, nil,
+			words, scanWords("test.sql", []byte(test.formatted)))
+		if test.valid && err != nil {
+			t.Errorf("Expected no error for %q, got: %v", test.formatted, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("Expected error for %q", test.formatted)
+		}
+	}
+}
+
+// Test for added semicolons which change statements of formatted script.
+func TestVerifySemicolons(t *testing.T) {
+	src := "SELECT a FROM t j SELECT b FROM u"
+	words := scanWords("test.sql", []byte(src))
+	cfg := printer.DefaultConfig
+	cfg.Semicolons = true
+
+	tests := []struct {
+		ends      []int
+		starts    []int
+		formatted string
+		valid     bool
+	}{
+		{[]int{4, 8}, []int{0, 5}, "SELECT a FROM t j; SELECT b FROM u;", true},
+		{[]int{3, 8}, []int{0, 5}, "SELECT a FROM t; j SELECT b FROM u;", false},
+	}
+	for _, test := range tests {
+		bounds := semicolons{ends: map[int]bool{}, starts: map[int]bool{}}
+		for _, offset := range test.ends {
+			bounds.ends[offset] = true
+		}
+		for _, offset := range test.starts {
+			bounds.starts[offset] = true
+		}
+		err := verify(&cfg, bounds, nil, nil, words,
+			scanWords("test.sql", []byte(test.formatted)))
+		if test.valid && err != nil {
+			t.Errorf("Expected no error for %q, got: %v", test.formatted, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("Expected error for %q", test.formatted)
+		}
+	}
+}
@@ -0,0 +1,377 @@
+package format
+
+import (
+	"mssfmt/ast"
+	"mssfmt/parser"
+	"mssfmt/token"
+	"strings"
+	"unicode"
+)
+
+// Reserved keywords of T-SQL which cannot be used as aliases without
+// brackets. GO is added, because it would end the batch.
+var reservedWords = map[string]bool{
+	"ADD": true, "ALL": true, "ALTER": true, "AND": true, "ANY": true,
+	"AS": true, "ASC": true, "AUTHORIZATION": true, "BACKUP": true,
+	"BEGIN": true, "BETWEEN": true, "BREAK": true, "BROWSE": true,
+	"BULK": true, "BY": true, "CASCADE": true, "CASE": true, "CHECK": true,
+	"CHECKPOINT": true, "CLOSE": true, "CLUSTERED": true, "COALESCE": true,
+	"COLLATE": true, "COLUMN": true, "COMMIT": true, "COMPUTE": true,
+	"CONSTRAINT": true, "CONTAINS": true, "CONTAINSTABLE": true,
+	"CONTINUE": true, "CONVERT": true, "CREATE": true, "CROSS": true,
+	"CURRENT": true, "CURRENT_DATE": true, "CURRENT_TIME": true,
+	"CURRENT_TIMESTAMP": true, "CURRENT_USER": true, "CURSOR": true,
+	"DATABASE": true, "DBCC": true, "DEALLOCATE": true, "DECLARE": true,
+	"DEFAULT": true, "DELETE": true, "DENY": true, "DESC": true,
+	"DISK": true, "DISTINCT": true, "DISTRIBUTED": true, "DOUBLE": true,
+	"DROP": true, "DUMP": true, "ELSE": true, "END": true, "ERRLVL": true,
+	"ESCAPE": true, "EXCEPT": true, "EXEC": true, "EXECUTE": true,
+	"EXISTS": true, "EXIT": true, "EXTERNAL": true, "FETCH": true,
+	"FILE": true, "FILLFACTOR": true, "FOR": true, "FOREIGN": true,
+	"FREETEXT": true, "FREETEXTTABLE": true, "FROM": true, "FULL": true,
+	"FUNCTION": true, "GO": true, "GOTO": true, "GRANT": true, "GROUP": true,
+	"HAVING": true, "HOLDLOCK": true, "IDENTITY": true,
+	"IDENTITY_INSERT": true, "IDENTITYCOL": true, "IF": true, "IN": true,
+	"INDEX": true, "INNER": true, "INSERT": true, "INTERSECT": true,
+	"INTO": true, "IS": true, "JOIN": true, "KEY": true, "KILL": true,
+	"LEFT": true, "LIKE": true, "LINENO": true, "LOAD": true, "MERGE": true,
+	"NATIONAL": true, "NOCHECK": true, "NONCLUSTERED": true, "NOT": true,
+	"NULL": true, "NULLIF": true, "OF": true, "OFF": true, "OFFSETS": true,
+	"ON": true, "OPEN": true, "OPENDATASOURCE": true, "OPENQUERY": true,
+	"OPENROWSET": true, "OPENXML": true, "OPTION": true, "OR": true,
+	"ORDER": true, "OUTER": true, "OVER": true, "PERCENT": true,
+	"PIVOT": true, "PLAN": true, "PRECISION": true, "PRIMARY": true,
+	"PRINT": true, "PROC": true, "PROCEDURE": true, "PUBLIC": true,
+	"RAISERROR": true, "READ": true, "READTEXT": true, "RECONFIGURE": true,
+	"REFERENCES": true, "REPLICATION": true, "RESTORE": true,
+	"RESTRICT": true, "RETURN": true, "REVERT": true, "REVOKE": true,
+	"RIGHT": true, "ROLLBACK": true, "ROWCOUNT": true, "ROWGUIDCOL": true,
+	"RULE": true, "SAVE": true, "SCHEMA": true, "SECURITYAUDIT": true,
+	"SELECT": true, "SEMANTICKEYPHRASETABLE": true,
+	"SEMANTICSIMILARITYDETAILSTABLE": true, "SEMANTICSIMILARITYTABLE": true,
+	"SESSION_USER": true, "SET": true,
+	"SETUSER": true, "SHUTDOWN": true, "SOME": true, "STATISTICS": true,
+	"SYSTEM_USER": true, "TABLE": true, "TABLESAMPLE": true,
+	"TEXTSIZE": true, "THEN": true, "TO": true, "TOP": true, "TRAN": true,
+	"TRANSACTION": true, "TRIGGER": true, "TRUNCATE": true,
+	"TRY_CONVERT": true, "TSEQUAL": true, "UNION": true, "UNIQUE": true,
+	"UNPIVOT": true, "UPDATE": true, "UPDATETEXT": true, "USE": true,
+	"USER": true, "VALUES": true, "VARYING": true, "VIEW": true,
+	"WAITFOR": true, "WHEN": true, "WHERE": true, "WHILE": true,
+	"WITH": true, "WITHIN": true, "WRITETEXT": true,
+}
+
+// Type edit is a column rewritten to the form "expression AS alias". Words
+// of the source at offsets of removed (the alias and "=" or AS next to it)
+// are removed and words of added (AS and the alias delimited by brackets
+// when needed) are inserted after the word at offset end, the last word of
+// the expression. Alias is offset of the alias in the source.
+type edit struct {
+	end     int
+	alias   int
+	removed []int
+	added   ast.Expression
+}
+
+// Type aliasRewriter rewrites aliases of SELECT columns in all queries of
+// the syntax tree and records edits of the source made by the rewrite. Added
+// AS keywords have no position, so the printer spells them as AS keywords of
+// the source next to them.
+type aliasRewriter struct {
+	edits []edit
+}
+
+// Function rewriteAliases rewrites columns of the form "alias = expression"
+// and columns with aliases given by string literals to the form
+// "expression AS alias", including columns of subqueries nested in
+// expressions. Assignments of variables (SELECT @v = expression) aren't
+// rewritten. Rewritten aliases are recorded as aliases of the script. It
+// returns edits of the source.
+func rewriteAliases(script *ast.Script) []edit {
+	r := aliasRewriter{}
+	for _, batch := range script.Batches {
+		walkStatements(batch.Statements, func(stmts []ast.Statement) {
+			for _, stmt := range stmts {
+				r.statement(stmt)
+			}
+		})
+	}
+	for _, e := range r.edits {
+		script.Aliases = append(script.Aliases, e.added[len(e.added)-1].Pos)
+	}
+	return r.edits
+}
+
+// Method statement rewrites queries of the statement. Statements nested in
+// it are rewritten separately and queries kept as raw statements aren't
+// rewritten.
+func (r *aliasRewriter) statement(stmt ast.Statement) {
+	switch s := stmt.(type) {
+	case *ast.SelectStatement:
+		r.selectStatement(s)
+	case *ast.ViewStatement:
+		r.selectStatement(s.Query)
+	case *ast.DeclareCursorStatement:
+		r.selectStatement(s.Query)
+	case *ast.ReturnStatement:
+		r.selectStatement(s.Query)
+	case *ast.UpdateStatement:
+		r.from(s.From)
+		r.where(s.Where)
+	}
+}
+
+// Method selectStatement rewrites common table expressions and all queries
+// of SELECT statement.
+func (r *aliasRewriter) selectStatement(stmt *ast.SelectStatement) {
+	if stmt == nil {
+		return
+	}
+	for _, cte := range stmt.With {
+		r.selectStatement(cte.Query)
+	}
+	r.selectQuery(stmt.Query)
+	for _, setOp := range stmt.SetOps {
+		r.selectQuery(setOp.Query)
+	}
+}
+
+// Method selectQuery rewrites columns of the query, derived tables of its
+// FROM clause and subqueries of its conditions.
+func (r *aliasRewriter) selectQuery(query *ast.SelectQuery) {
+	if query == nil {
+		return
+	}
+	for id, col := range query.Columns {
+		col = r.expression(col)
+		if e := rewriteColumn(col); e != nil {
+			r.edits = append(r.edits, *e)
+			col = applyEdits(col, []edit{*e})
+		}
+		query.Columns[id] = col
+	}
+	r.from(query.From)
+	r.where(query.Where)
+	if query.Having != nil {
+		query.Having.Condition = r.expression(query.Having.Condition)
+	}
+}
+
+// Method from rewrites derived tables of FROM clause and subqueries of
+// conditions of joins.
+func (r *aliasRewriter) from(from *ast.FromClause) {
+	if from == nil {
+		return
+	}
+	r.selectStatement(from.TableOrViewName.Subquery)
+	for id := range from.Joins {
+		join := &from.Joins[id]
+		r.selectStatement(join.RightTableName.Subquery)
+		join.Condition = r.expression(join.Condition)
+	}
+}
+
+// Method where rewrites subqueries of WHERE clause.
+func (r *aliasRewriter) where(where *ast.WhereClause) {
+	if where != nil {
+		where.Condition = r.expression(where.Condition)
+	}
+}
+
+// Method expression rewrites columns of subqueries nested in the expression,
+// like scalar subqueries or subqueries of EXISTS and IN. It returns the
+// expression with rewritten subqueries.
+func (r *aliasRewriter) expression(expr ast.Expression) ast.Expression {
+	nested := aliasRewriter{}
+	for id := 0; id+1 < len(expr); id++ {
+		if expr[id].Token != token.LPAREN || expr[id+1].Token != token.SELECT {
+			continue
+		}
+		end := expr.ClosingParen(id)
+		if end == len(expr) {
+			break
+		}
+		nested.subquery(expr[id+1 : end])
+		id = end
+	}
+	if len(nested.edits) == 0 {
+		return expr
+	}
+	r.edits = append(r.edits, nested.edits...)
+	return applyEdits(expr, nested.edits)
+}
+
+// Method subquery rewrites columns of a subquery given as a flat list of
+// words. Words which aren't a single SELECT statement aren't rewritten.
+func (r *aliasRewriter) subquery(words ast.Expression) {
+	var p parser.Parser
+	p.Init("", parser.Words(words))
+	script := p.Script()
+	if len(script.Batches) != 1 || len(script.Batches[0].Statements) != 1 {
+		return
+	}
+	stmt, ok := script.Batches[0].Statements[0].(*ast.SelectStatement)
+	if ok && stmt.End().Offset == words[len(words)-1].Pos.Offset {
+		r.selectStatement(stmt)
+	}
+}
+
+// Function rewriteColumn returns edit of SELECT column which rewrites it to
+// the form "expression AS alias" or nil when the column isn't rewritten.
+func rewriteColumn(col ast.Expression) *edit {
+	n := len(col)
+	if n < 2 {
+		return nil
+	}
+
+	var expr, removed ast.Expression
+	var alias ast.Word
+	switch {
+	case n > 2 && col[1].Token == token.ASSIGN &&
+		(col[0].Token == token.IDENT && !strings.HasPrefix(col[0].Literal, "@") ||
+			isStringAlias(col[0])):
+		expr, removed, alias = col[2:], col[:2], col[0]
+	case isStringAlias(col[n-1]) && col[n-2].Token == token.AS && n > 2:
+		expr, removed, alias = col[:n-2], col[n-2:], col[n-1]
+	case isStringAlias(col[n-1]) && parser.IsOperand(col[n-2]) &&
+		!(n > 2 && parser.IsWord(col[n-3], "TIME") &&
+			parser.IsWord(col[n-2], "ZONE")):
+		expr, removed, alias = col[:n-1], col[n-1:], col[n-1]
+	default:
+		return nil
+	}
+
+	e := edit{end: expr[len(expr)-1].Pos.Offset, alias: alias.Pos.Offset}
+	for _, word := range removed {
+		e.removed = append(e.removed, word.Pos.Offset)
+	}
+	if alias.Token == token.STRING {
+		alias.Token, alias.Literal = token.IDENT, quoteAlias(alias.Literal)
+	}
+	e.added = ast.Expression{{Token: token.AS, Literal: "AS"}, alias}
+	return &e
+}
+
+// Function isStringAlias checks if the word is a string literal which can be
+// used as an alias. Unicode strings and empty strings aren't aliases.
+func isStringAlias(w ast.Word) bool {
+	return w.Token == token.STRING && strings.HasPrefix(w.Literal, "'") &&
+		len(w.Literal) > 2
+}
+
+// Function quoteAlias returns identifier of an alias given by string
+// literal. Alias is delimited by brackets unless it's a regular identifier
+// which isn't a reserved keyword.
+func quoteAlias(literal string) string {
+	alias := strings.Replace(literal[1:len(literal)-1], "''", "'", -1)
+	upper := strings.ToUpper(alias)
+	if isRegularIdentifier(alias) && !reservedWords[upper] &&
+		token.KeywordLookup(upper) == token.IDENT {
+		return alias
+	}
+	return "[" + strings.Replace(alias, "]", "]]", -1) + "]"
+}
+
+// Function isRegularIdentifier checks if the name is a regular identifier,
+// which starts with a letter or underscore followed by letters, digits,
+// underscores, at signs, number signs or dollar signs.
+func isRegularIdentifier(name string) bool {
+	for id, char := range name {
+		switch {
+		case unicode.IsLetter(char) || char == '_':
+		case id > 0 && (unicode.IsDigit(char) || char == '@' || char == '#' ||
+			char == '$'):
+		default:
+			return false
+		}
+	}
+	return name != ""
+}
+
+// Function applyEdits returns words with given edits applied. Words are
+// identified by offsets of their positions, so edits may be applied to any
+// part of the source. Edits are applied to structures of CASE expressions and
+// window specifications as well.
+func applyEdits(words ast.Expression, edits []edit) ast.Expression {
+	removed := make(map[int]bool)
+	added := make(map[int]ast.Expression, len(edits))
+	for _, e := range edits {
+		for _, offset := range e.removed {
+			removed[offset] = true
+		}
+		added[e.end] = e.added
+	}
+
+	result := make(ast.Expression, 0, len(words)+2*len(edits))
+	for _, word := range words {
+		if word.Case != nil {
+			word.Case = editCase(word.Case, edits)
+		}
+		if word.Window != nil {
+			word.Window = editWindow(word.Window, edits)
+		}
+		if !removed[word.Pos.Offset] {
+			result = append(result, word)
+		}
+		result = append(result, added[word.Pos.Offset]...)
+	}
+	return result
+}
+
+// Function editCase returns copy of CASE expression with given edits applied
+// to its parts.
+func editCase(caseExpr *ast.CaseExpr, edits []edit) *ast.CaseExpr {
+	edited := *caseExpr
+	if edited.Input != nil {
+		edited.Input = applyEdits(edited.Input, edits)
+	}
+	edited.Whens = make([]*ast.WhenClause, len(caseExpr.Whens))
+	for id, when := range caseExpr.Whens {
+		edited.Whens[id] = &ast.WhenClause{
+			Condition: applyEdits(when.Condition, edits),
+			Result:    applyEdits(when.Result, edits),
+		}
+	}
+	if edited.Else != nil {
+		edited.Else = applyEdits(edited.Else, edits)
+	}
+	return &edited
+}
+
+// Function editWindow returns copy of window specification with given edits
+// applied to items of its PARTITION BY and ORDER BY clauses.
+func editWindow(spec *ast.WindowSpec, edits []edit) *ast.WindowSpec {
+	edited := *spec
+	edited.PartitionBy = editList(spec.PartitionBy, edits)
+	edited.OrderBy = editList(spec.OrderBy, edits)
+	return &edited
+}
+
+// Function editList returns copy of expressions with given edits applied.
+// Nil list stays nil.
+func editList(exprs []ast.Expression, edits []edit) []ast.Expression {
+	if exprs == nil {
+		return nil
+	}
+	edited := make([]ast.Expression, len(exprs))
+	for id, expr := range exprs {
+		edited[id] = applyEdits(expr, edits)
+	}
+	return edited
+}
+
+// Function aliasName returns name of an alias given by identifier or string
+// literal, without delimiters and with escaped characters unescaped.
+func aliasName(w ast.Word) string {
+	lit := w.Literal
+	switch {
+	case w.Token == token.STRING && strings.HasPrefix(lit, "'"):
+		return strings.Replace(lit[1:len(lit)-1], "''", "'", -1)
+	case strings.HasPrefix(lit, "[") && strings.HasSuffix(lit, "]"):
+		return strings.Replace(lit[1:len(lit)-1], "]]", "]", -1)
+	case strings.HasPrefix(lit, `"`) && strings.HasSuffix(lit, `"`) &&
+		len(lit) > 1:
+		return strings.Replace(lit[1:len(lit)-1], `""`, `"`, -1)
+	}
+	return lit
+}
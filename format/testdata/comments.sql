@@ -0,0 +1,24 @@
+-- Header of the script
+/* block comment */
+select a, -- first column
+	b /* second */, c
+from dbo.t x -- table
+where a = 1 -- filter
+	and b = 2
+
+-- before the procedure
+create procedure dbo.p
+	@a int, -- first
+	-- before second
+	@b int /* second */
+as -- body follows
+begin -- start
+	declare @x int = 1, -- counter
+		@y int
+	-- before exec
+	exec dbo.q @a = @a, -- pass a
+		@b = @b
+	-- at the end
+end
+GO -- after go
+-- end of script
@@ -0,0 +1,25 @@
+declare @i int = 0, @n int = 10
+declare orders cursor local fast_forward for select OrderId from dbo.Orders
+open orders
+fetch next from orders into @id
+while @@fetch_status = 0
+begin
+	set @i += 1
+	if @i % 2 = 0 continue
+	else if @i > @n begin break end
+	else print @i
+	fetch next from orders into @id
+end
+close orders
+deallocate orders
+
+begin try
+	begin tran t1
+	update dbo.Orders set Qty = Qty + 1 where OrderId = @id
+	commit tran t1
+end try
+begin catch
+	if @@trancount > 0 rollback;
+	throw;
+end catch
+done: return
@@ -0,0 +1,37 @@
+/*
+ * Returns orders of a customer.
+ */
+create or alter procedure dbo.usp_GetOrders
+	@CustomerId int, -- required
+	@From date = null, @To date = null,
+	@Total decimal(18,2) output
+with recompile
+as
+begin
+	set nocount on;
+	declare @rows int = 0, @msg nvarchar(200)
+
+	select o.OrderId, o.OrderDate, sum(l.Price*l.Qty) as Total
+	from dbo.Orders o
+	join dbo.OrderLines l on l.OrderId = o.OrderId
+	where o.CustomerId = @CustomerId and (@From is null or o.OrderDate >= @From)
+	group by o.OrderId, o.OrderDate
+	order by o.OrderDate desc
+
+	set @rows = @@rowcount
+	if @rows = 0
+		raiserror('No orders for customer %d', 10, 1, @CustomerId) with nowait
+	else
+	begin
+		select @Total = sum(Total) from #totals -- computed above
+		exec dbo.usp_Log @Procedure = 'usp_GetOrders', @Rows = @rows, @Message = @msg output
+	end
+end
+GO
+create function dbo.fn_Net(@Gross decimal(18,2), @Rate decimal(5,2) = 0.23)
+returns decimal(18,2)
+with schemabinding
+as
+begin
+	return @Gross / (1 + @Rate)
+end
@@ -0,0 +1,22 @@
+with recent (CustomerId, Orders) as (
+	select CustomerId, count(*) from dbo.Orders where OrderDate > dateadd(day, -30, getdate()) group by CustomerId
+), best as (select top 10 CustomerId from recent order by Orders desc)
+select distinct c.CustomerId, c.Name, r.Orders,
+	case when r.Orders > 100 then 'gold' when r.Orders > 10 then 'silver' else 'bronze' end as Tier,
+	row_number() over (partition by c.Region order by r.Orders desc) as Position
+from dbo.Customers c with (nolock)
+inner join recent r on r.CustomerId = c.CustomerId
+left outer join (select CustomerId, max(OrderDate) as LastOrder from dbo.Orders group by CustomerId) lo on lo.CustomerId = c.CustomerId
+cross apply dbo.fn_Addresses(c.CustomerId) a
+where exists (select 1 from best b where b.CustomerId = c.CustomerId)
+order by r.Orders desc
+option (recompile)
+
+select Region, [2019], [2020]
+from (select Region, year(OrderDate) as OrderYear, Total from dbo.Orders) s
+pivot (sum(Total) for OrderYear in ([2019], [2020])) as p
+
+select Id, Name from dbo.Products for system_time as of @date
+union all
+select Id, Name from dbo.ArchivedProducts
+for json path, root('products')
@@ -0,0 +1,15 @@
+create table dbo.Orders (
+	OrderId int identity(1,1) not null constraint PK_Orders primary key clustered,
+	CustomerId int not null,
+	OrderDate datetime2(0) not null default sysutcdatetime(),
+	Price decimal(18,2), Qty int,
+	Total as Price*Qty persisted,
+	constraint FK_Orders_Customers foreign key (CustomerId) references dbo.Customers (CustomerId) on delete cascade,
+	check (Qty > 0)
+) on [PRIMARY]
+GO
+alter table dbo.Orders add Comment nvarchar(max) null, Flags tinyint not null default 0
+alter table dbo.Orders alter column Comment nvarchar(4000) null
+alter table dbo.Orders drop constraint if exists DF_Flags, column Flags
+create nonclustered index IX_Orders_Customer on dbo.Orders (CustomerId asc, OrderDate desc) include (Total) with (online = on)
+drop table if exists #tmp, #totals
@@ -0,0 +1,13 @@
+-- Long expressions are wrapped at operators and arguments
+select o.OrderId, concat(c.FirstName, ' ', c.LastName, ' <', c.Email, '>', ' ordered ', o.Quantity, ' pieces of ', p.ProductName) as Description,
+  coalesce(o.ShippingAddress, c.DefaultShippingAddress, c.BillingAddress, c.CompanyAddress, 'unknown') as Address
+from dbo.Orders o join dbo.Customers c on c.CustomerId = o.CustomerId and c.IsActive = 1 and c.DeletedAt is null and c.Region = o.Region
+join dbo.Products p on p.ProductId = o.ProductId
+where o.OrderDate >= dateadd(month, -6, getdate()) and (o.Status = 'shipped' or o.Status = 'delivered') -- done
+  and o.Quantity * p.UnitPrice - coalesce(o.Discount, 0) > 1000
+GO
+
+while (select count(*) from dbo.Queue where ProcessedAt is null and RetryCount < 5) > 0 and @Iterations < @MaxIterations
+begin
+    set @Iterations = @Iterations + 1
+end
@@ -1,41 +1,133 @@
 package main
 
 import (
-	"fmt"
+	"flag"
+	"io/ioutil"
 	"log"
 	"os"
 
+	"mssfmt/format"
+	"mssfmt/printer"
 	"mssfmt/read"
-	"mssfmt/scanner"
-	"mssfmt/token"
+)
+
+// Formatting options given in the command line. They override options of the
+// configuration file.
+var (
+	indentWidth = flag.Int("indent", printer.DefaultConfig.IndentWidth,
+		"number of spaces of a single level of indentation")
+	useTabs = flag.Bool("tabs", printer.DefaultConfig.UseTabs,
+		"indent with tabs instead of spaces")
+	lineWidth = flag.Int("width", printer.DefaultConfig.LineWidth,
+		"maximum width of a line")
+	leadingCommas = flag.Bool("leading-commas",
+		printer.DefaultConfig.LeadingCommas,
+		"place commas of lists at the beginning of lines")
+	collapseLists = flag.Bool("collapse", printer.DefaultConfig.CollapseLists,
+		"print short lists in a single line")
+	alignThreshold = flag.Int("align", printer.DefaultConfig.AlignThreshold,
+		"maximum width difference of aligned cells, 0 disables alignment")
+	semicolons = flag.Bool("semicolons", printer.DefaultConfig.Semicolons,
+		"add missing semicolons terminating statements")
+	rewriteAliases = flag.Bool("rewrite-aliases",
+		format.DefaultConfig.RewriteAliases,
+		"rewrite column aliases to the form expression AS alias")
+	keywordCase  = printer.DefaultConfig.KeywordCase
+	functionCase = printer.DefaultConfig.FunctionCase
+	typeCase     = printer.DefaultConfig.TypeCase
+	aliasKeyword = printer.DefaultConfig.AliasKeyword
+	outerKeyword = printer.DefaultConfig.OuterKeyword
+	innerKeyword = printer.DefaultConfig.InnerKeyword
 )
 
 func main() {
-	var outputPath string
-	args := os.Args[1:]
+	flag.Var(&keywordCase, "keywords",
+		"case of keywords: upper, lower or preserve")
+	flag.Var(&functionCase, "functions",
+		"case of built-in functions: upper, lower or preserve")
+	flag.Var(&typeCase, "types",
+		"case of built-in data types: upper, lower or preserve")
+	flag.Var(&aliasKeyword, "alias-as",
+		"AS keyword of aliases: keep, always or never")
+	flag.Var(&outerKeyword, "outer",
+		"OUTER keyword of outer joins: keep, always or never")
+	flag.Var(&innerKeyword, "inner",
+		"INNER keyword of inner joins: keep, always or never")
+	flag.Parse()
+	args := flag.Args()
 
 	if len(args) < 1 {
 		log.Panic("SQL script name or path is needed.")
 	}
-	outputPath = args[0]
+	inputPath := args[0]
 
-	if len(args) >= 2 {
-		outputPath = args[1]
-	}
-
-	scriptRaw, readErr := read.SQLScript(outputPath)
+	scriptRaw, readErr := read.SQLScript(inputPath)
 	if readErr != nil {
 		log.Panic(readErr)
 	}
 
-	var scriptScanner scanner.Scanner
-	scriptScanner.Init(outputPath, []byte(scriptRaw.Content))
+	cfg, configErr := config(inputPath)
+	if configErr != nil {
+		log.Fatal(configErr)
+	}
+
+	// Formatted script isn't written if it isn't equivalent to the source.
+	formatted, formatErr := format.SourceConfig(&cfg, inputPath,
+		[]byte(scriptRaw.Content))
+	if formatErr != nil {
+		log.Fatal(formatErr)
+	}
 
-	for {
-		tok, lit := scriptScanner.Scan()
-		if tok == token.EOF {
-			break
+	if len(args) >= 2 {
+		if writeErr := ioutil.WriteFile(args[1], formatted, 0644); writeErr != nil {
+			log.Panic(writeErr)
 		}
-		fmt.Printf("[%s] %s \n", tok, lit)
+		return
 	}
+	if _, writeErr := os.Stdout.Write(formatted); writeErr != nil {
+		log.Panic(writeErr)
+	}
+}
+
+// Function config returns formatting style of the script at given path. It's
+// read from the configuration file and then flags given explicitly in the
+// command line are applied.
+func config(path string) (format.Config, error) {
+	cfg, err := format.LoadConfig(path)
+	if err != nil {
+		return cfg, err
+	}
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "indent":
+			cfg.IndentWidth = *indentWidth
+		case "tabs":
+			cfg.UseTabs = *useTabs
+		case "width":
+			cfg.LineWidth = *lineWidth
+		case "keywords":
+			cfg.KeywordCase = keywordCase
+		case "functions":
+			cfg.FunctionCase = functionCase
+		case "types":
+			cfg.TypeCase = typeCase
+		case "leading-commas":
+			cfg.LeadingCommas = *leadingCommas
+		case "collapse":
+			cfg.CollapseLists = *collapseLists
+		case "align":
+			cfg.AlignThreshold = *alignThreshold
+		case "alias-as":
+			cfg.AliasKeyword = aliasKeyword
+		case "outer":
+			cfg.OuterKeyword = outerKeyword
+		case "inner":
+			cfg.InnerKeyword = innerKeyword
+		case "semicolons":
+			cfg.Semicolons = *semicolons
+		case "rewrite-aliases":
+			cfg.RewriteAliases = *rewriteAliases
+		}
+	})
+	return cfg, format.CheckConfig(cfg)
 }
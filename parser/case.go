@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+)
+
+// Function caseExpr builds ast.CaseExpr from words of flat expression which
+// starts with CASE keyword. Position of the last word of the expression is
+// used as its end when END keyword is missing.
+func caseExpr(expr ast.Expression) *ast.CaseExpr {
+	var p Parser
+	p.Init("", Words(expr))
+	p.next()
+
+	caseExpr := ast.CaseExpr{}
+	if input := p.expression(isCaseWord(token.WHEN, token.ELSE,
+		token.END)); len(input) > 0 {
+		caseExpr.Input = input
+	}
+	for p.word.Token == token.WHEN {
+		p.next()
+		when := ast.WhenClause{Condition: p.expression(isCaseWord(token.THEN))}
+		if p.word.Token == token.THEN {
+			p.next()
+		}
+		when.Result = p.expression(isCaseWord(token.WHEN, token.ELSE,
+			token.END))
+		caseExpr.Whens = append(caseExpr.Whens, &when)
+	}
+	if p.word.Token == token.ELSE {
+		p.next()
+		caseExpr.Else = p.expression(isCaseWord(token.END))
+	}
+	if p.word.Token == token.END {
+		p.next()
+	}
+	caseExpr.End = p.prev.Pos
+	return &caseExpr
+}
+
+// Function isCaseWord returns stop function for parts of CASE expression
+// which end on any of given keywords.
+func isCaseWord(keywords ...token.Token) func(ast.Word) bool {
+	return func(w ast.Word) bool {
+		for _, keyword := range keywords {
+			if w.Token == keyword {
+				return true
+			}
+		}
+		return false
+	}
+}
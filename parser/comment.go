@@ -0,0 +1,188 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+	"sort"
+)
+
+// Type nodeRange keeps indexes of the first and the last word of a batch.
+// It's used for associating comments with batches.
+type nodeRange struct {
+	node  ast.Node
+	start int
+	end   int
+}
+
+// Method commentMap associates all comments of the script with statements,
+// batches or the script itself. Consecutive comments form a single group,
+// but comments in the line of the preceding word and comments in the
+// following lines are separate groups.
+func (p *Parser) commentMap(script *ast.Script) ast.CommentMap {
+	cmap := make(ast.CommentMap)
+	index := newStatementIndex(p.statements)
+
+	for first := 0; first < len(p.source); first++ {
+		if p.source[first].Token != token.COMMENT {
+			continue
+		}
+		last := first
+		for last+1 < len(p.source) && p.source[last+1].Token == token.COMMENT {
+			last++
+		}
+
+		split := first
+		if first > 0 {
+			line := p.source[first-1].Pos.Line
+			for split <= last && p.source[split].Pos.Line == line {
+				split++
+			}
+		}
+		if split > first {
+			p.associate(cmap, script, index, first, split-1, false)
+		}
+		if split <= last {
+			p.associate(cmap, script, index, split, last, true)
+		}
+		first = last
+	}
+	return cmap
+}
+
+// Method associate adds comments between given indexes (inclusive) to the
+// comment map as a single group. Field newline of the group is true when
+// comments don't start in the line of the preceding word. Comments in the
+// line of the preceding word stay in the statement containing them, other
+// comments rather precede the next statement.
+func (p *Parser) associate(cmap ast.CommentMap, script *ast.Script,
+	index *statementIndex, first, last int, newline bool) {
+	group := ast.CommentGroup{Newline: newline}
+	for _, word := range p.source[first : last+1] {
+		group.List = append(group.List,
+			&ast.Comment{Text: word.Literal, Pos: word.Pos})
+	}
+
+	var node ast.Node
+	switch {
+	case !newline && index.endingAt(first-1) != nil:
+		group.Kind = ast.TRAILINGCOMMENT
+		node = index.endingAt(first - 1)
+	case !newline && index.containing(first, last) != nil:
+		group.Kind = ast.INNERCOMMENT
+		node = index.containing(first, last)
+	case index.startingAt(last+1) != nil:
+		group.Kind = ast.LEADINGCOMMENT
+		node = index.startingAt(last + 1)
+	case index.containing(first, last) != nil:
+		group.Kind = ast.INNERCOMMENT
+		node = index.containing(first, last)
+	default:
+		group.Kind = ast.TRAILINGCOMMENT
+		node = script
+		if batch := p.batchAt(last + 1); batch != nil {
+			node = batch
+		}
+	}
+	cmap[node] = append(cmap[node], &group)
+}
+
+// Method batchAt returns the batch which contains given word or nil if there
+// is no such batch. Batches are recorded in order of the script, so they are
+// binary searched.
+func (p *Parser) batchAt(offset int) ast.Node {
+	id := sort.Search(len(p.batches), func(id int) bool {
+		return p.batches[id].end >= offset
+	})
+	if id < len(p.batches) && p.batches[id].start <= offset {
+		return p.batches[id].node
+	}
+	return nil
+}
+
+// Type statementIndex indexes parsed statements by offsets of their first
+// and last words, so statements around comments are found without scanning
+// all statements for each comment. Sorted contains statements ordered by
+// their first words (outer statements before inner ones) and parent keeps
+// index of the innermost statement enclosing each of them or -1.
+type statementIndex struct {
+	starts map[int]ast.Statement
+	ends   map[int]ast.Statement
+	sorted []ast.Statement
+	parent []int
+}
+
+// Function newStatementIndex builds index of given statements.
+func newStatementIndex(stmts []ast.Statement) *statementIndex {
+	index := statementIndex{
+		starts: make(map[int]ast.Statement, len(stmts)),
+		ends:   make(map[int]ast.Statement, len(stmts)),
+		sorted: make([]ast.Statement, len(stmts)),
+		parent: make([]int, len(stmts)),
+	}
+	for _, stmt := range stmts {
+		start, end := stmt.Pos().Offset, stmt.End().Offset
+		if found, ok := index.starts[start]; !ok || end > found.End().Offset {
+			index.starts[start] = stmt
+		}
+		if found, ok := index.ends[end]; !ok || start > found.Pos().Offset {
+			index.ends[end] = stmt
+		}
+	}
+
+	copy(index.sorted, stmts)
+	sort.SliceStable(index.sorted, func(i, j int) bool {
+		a, b := index.sorted[i], index.sorted[j]
+		if a.Pos().Offset != b.Pos().Offset {
+			return a.Pos().Offset < b.Pos().Offset
+		}
+		return a.End().Offset > b.End().Offset
+	})
+	open := make([]int, 0, 8)
+	for id, stmt := range index.sorted {
+		for len(open) > 0 &&
+			index.sorted[open[len(open)-1]].End().Offset < stmt.Pos().Offset {
+			open = open[:len(open)-1]
+		}
+		index.parent[id] = -1
+		if len(open) > 0 {
+			index.parent[id] = open[len(open)-1]
+		}
+		open = append(open, id)
+	}
+	return &index
+}
+
+// Method endingAt returns the innermost statement which ends at given word
+// or nil if there is no such statement.
+func (index *statementIndex) endingAt(end int) ast.Node {
+	if stmt, ok := index.ends[end]; ok {
+		return stmt
+	}
+	return nil
+}
+
+// Method startingAt returns the outermost statement which starts at given
+// word or nil if there is no such statement.
+func (index *statementIndex) startingAt(start int) ast.Node {
+	if stmt, ok := index.starts[start]; ok {
+		return stmt
+	}
+	return nil
+}
+
+// Method containing returns the innermost statement which contains words
+// between given indexes or nil if there is no such statement. It finds the
+// last statement starting before the words and climbs up its enclosing
+// statements until one of them ends after the words.
+func (index *statementIndex) containing(first, last int) ast.Node {
+	id := sort.Search(len(index.sorted), func(id int) bool {
+		return index.sorted[id].Pos().Offset >= first
+	}) - 1
+	for id >= 0 && index.sorted[id].End().Offset <= last {
+		id = index.parent[id]
+	}
+	if id < 0 {
+		return nil
+	}
+	return index.sorted[id]
+}
@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"testing"
+)
+
+// Test for associating comments with statements, batches and the script.
+func TestCommentMap(t *testing.T) {
+	p := testParser(`-- header
+/* block */
+SELECT a, -- column
+	b
+FROM t; -- trailing
+IF a = 1
+	-- leading of SET
+	SET @x = 1
+BEGIN
+	PRINT 1
+	-- inner of block
+END
+-- end of batch
+GO
+-- end of script`)
+	script := p.Script()
+	cmap := script.Comments
+	stmts := script.Batches[0].Statements
+	if len(stmts) != 3 {
+		t.Fatalf("Expected 3 statements, got: %d", len(stmts))
+	}
+
+	tests := []struct {
+		node  ast.Node
+		kind  ast.CommentKind
+		texts []string
+	}{
+		{stmts[0], ast.LEADINGCOMMENT, []string{"-- header", "/* block */"}},
+		{stmts[0], ast.INNERCOMMENT, []string{"-- column"}},
+		{stmts[0], ast.TRAILINGCOMMENT, []string{"-- trailing"}},
+		{stmts[1].(*ast.IfStatement).Then, ast.LEADINGCOMMENT,
+			[]string{"-- leading of SET"}},
+		{stmts[2], ast.INNERCOMMENT, []string{"-- inner of block"}},
+		{script.Batches[0], ast.TRAILINGCOMMENT, []string{"-- end of batch"}},
+		{script, ast.TRAILINGCOMMENT, []string{"-- end of script"}},
+	}
+
+	for _, test := range tests {
+		groups := cmap.Filter(test.node, test.kind)
+		if len(groups) != 1 || len(groups[0].List) != len(test.texts) {
+			t.Errorf("Expected 1 group with %v, got: %v", test.texts, groups)
+			continue
+		}
+		for id, comment := range groups[0].List {
+			if comment.Text != test.texts[id] {
+				t.Errorf("Expected comment %s, got: %s", test.texts[id],
+					comment.Text)
+			}
+		}
+	}
+
+	if groups := cmap.Comments(); len(groups) != 7 {
+		t.Errorf("Expected 7 comment groups, got: %d", len(groups))
+	}
+	if groups := cmap.Filter(stmts[0], ast.INNERCOMMENT); len(groups) == 1 &&
+		groups[0].Newline {
+		t.Errorf("Expected comment in the line of preceding word")
+	}
+	if pos := stmts[0].Pos(); pos.Line != 3 || pos.Column != 1 {
+		t.Errorf("Expected SELECT at 3:1, got: %s", pos)
+	}
+	if end := stmts[0].End(); end.Line != 5 || end.Column != 7 {
+		t.Errorf("Expected semicolon at 5:7, got: %s", end)
+	}
+}
+
+// Test for associating comments with the innermost statement containing them
+// after nested statements which end before the comments.
+func TestCommentMapNested(t *testing.T) {
+	p := testParser(`CREATE PROCEDURE p AS
+BEGIN
+	SELECT 1
+	WHILE 1 = 1
+	BEGIN
+		SELECT 2
+		PRINT 3
+		-- inner of loop
+	END
+	-- inner of body
+END`)
+	script := p.Script()
+	cmap := script.Comments
+	proc := script.Batches[0].Statements[0].(*ast.ProcedureStatement)
+	body := proc.Body[0].(*ast.BlockStatement)
+	loop := body.Statements[1].(*ast.WhileStatement).Body
+
+	tests := []struct {
+		node ast.Node
+		text string
+	}{
+		{loop, "-- inner of loop"},
+		{body, "-- inner of body"},
+	}
+	for _, test := range tests {
+		groups := cmap.Filter(test.node, ast.INNERCOMMENT)
+		if len(groups) != 1 || groups[0].List[0].Text != test.text {
+			t.Errorf("Expected inner comment %s, got: %v", test.text, groups)
+		}
+	}
+}
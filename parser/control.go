@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+)
+
+// Method ifStatement parses IF ... ELSE statement. This method assumes that
+// current word is IF.
+func (p *Parser) ifStatement() *ast.IfStatement {
+	stmt := ast.IfStatement{}
+	p.next()
+	stmt.Condition = p.condition()
+	stmt.Then = p.statement()
+
+	if p.word.Token == token.ELSE {
+		p.next()
+		stmt.Else = p.statement()
+	}
+	return &stmt
+}
+
+// Method whileStatement parses WHILE loop. This method assumes that current
+// word is WHILE.
+func (p *Parser) whileStatement() *ast.WhileStatement {
+	stmt := ast.WhileStatement{}
+	p.next()
+	stmt.Condition = p.condition()
+	stmt.Body = p.statement()
+	return &stmt
+}
+
+// Method condition parses condition of IF and WHILE statements. Condition
+// ends on the beginning of a statement outside of parentheses, except for
+// UPDATE(column) function used in triggers.
+func (p *Parser) condition() ast.Expression {
+	return p.expression(func(w ast.Word) bool {
+		if w.Token == token.UPDATE && p.peek().Token == token.LPAREN {
+			return false
+		}
+		return w.Token == token.SEMICOLON || p.isStatementStart()
+	})
+}
+
+// Method gotoStatement parses GOTO statement. This method assumes that
+// current word is GOTO.
+func (p *Parser) gotoStatement() *ast.GotoStatement {
+	p.next()
+	stmt := ast.GotoStatement{Label: p.word.Literal}
+	p.next()
+	return &stmt
+}
+
+// Method labelStatement parses label definition. This method assumes that
+// isLabel returned true.
+func (p *Parser) labelStatement() *ast.LabelStatement {
+	stmt := ast.LabelStatement{Label: p.word.Literal}
+	p.next()
+	p.next()
+	return &stmt
+}
+
+// Method isLabel checks if current word is a label definition, which is an
+// identifier followed by a colon. Double colon (like in SCHEMA::dbo) isn't a
+// label.
+func (p *Parser) isLabel() bool {
+	return p.word.Token == token.IDENT && p.peek().Token == token.COLON &&
+		p.peekN(2).Token != token.COLON
+}
@@ -0,0 +1,84 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"testing"
+)
+
+// Test for parsing IF ... ELSE statements.
+func TestParseIf(t *testing.T) {
+	p := testParser(`IF EXISTS (SELECT 1 FROM t) AND @a > 0 SELECT 1;
+	ELSE IF UPDATE(col) BEGIN PRINT 'x' END
+	ELSE SET @a = 1
+	IF @b IS NULL RETURN`)
+
+	stmt, isIf := p.statement().(*ast.IfStatement)
+	if !isIf {
+		t.Fatalf("Expected IF statement")
+	}
+	if len(stmt.Condition) != 11 {
+		t.Errorf("Expected condition of 11 words, got: %v", stmt.Condition)
+	}
+	if then, isSelect := stmt.Then.(*ast.SelectStatement); !isSelect ||
+		!then.Terminated() {
+		t.Errorf("Expected terminated SELECT, got: %T", stmt.Then)
+	}
+	elseIf, isIf := stmt.Else.(*ast.IfStatement)
+	if !isIf || len(elseIf.Condition) != 4 {
+		t.Fatalf("Expected ELSE IF UPDATE(col), got: %v", stmt.Else)
+	}
+	if _, isBlock := elseIf.Then.(*ast.BlockStatement); !isBlock {
+		t.Errorf("Expected BEGIN ... END block, got: %T", elseIf.Then)
+	}
+	if _, isSet := elseIf.Else.(*ast.SetStatement); !isSet {
+		t.Errorf("Expected SET statement, got: %T", elseIf.Else)
+	}
+
+	stmt = p.statement().(*ast.IfStatement)
+	if _, isReturn := stmt.Then.(*ast.ReturnStatement); !isReturn ||
+		stmt.Else != nil {
+		t.Errorf("Expected IF ... RETURN without ELSE, got: %v", stmt)
+	}
+}
+
+// Test for parsing WHILE loop, BREAK, CONTINUE, GOTO and labels.
+func TestParseWhile(t *testing.T) {
+	p := testParser(`WHILE @i < 10
+	BEGIN
+		SET @i = @i + 1
+		IF @i = 5 CONTINUE
+		IF @i = 8 BREAK;
+		GOTO done
+	END
+	done:
+	ALTER AUTHORIZATION ON SCHEMA::dbo TO r`)
+
+	loop, isWhile := p.statement().(*ast.WhileStatement)
+	if !isWhile || len(loop.Condition) != 3 {
+		t.Fatalf("Expected WHILE statement, got: %v", loop)
+	}
+	body := loop.Body.(*ast.BlockStatement).Statements
+	if len(body) != 4 {
+		t.Fatalf("Expected 4 statements in loop, got: %d", len(body))
+	}
+	if _, isContinue := body[1].(*ast.IfStatement).Then.(*ast.ContinueStatement); !isContinue {
+		t.Errorf("Expected CONTINUE statement")
+	}
+	brk, isBreak := body[2].(*ast.IfStatement).Then.(*ast.BreakStatement)
+	if !isBreak || !brk.Terminated() {
+		t.Errorf("Expected terminated BREAK statement")
+	}
+	if jump, isGoto := body[3].(*ast.GotoStatement); !isGoto ||
+		jump.Label != "done" {
+		t.Errorf("Expected GOTO done, got: %v", body[3])
+	}
+
+	if label, isLabel := p.statement().(*ast.LabelStatement); !isLabel ||
+		label.Label != "done" {
+		t.Errorf("Expected label done")
+	}
+	if raw, isRaw := p.statement().(*ast.RawStatement); !isRaw ||
+		len(raw.Words) != 9 {
+		t.Errorf("Expected raw ALTER statement, got: %v", raw)
+	}
+}
@@ -0,0 +1,114 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+)
+
+// Method declareCursor parses declaration of a named cursor. This method
+// assumes that current word is DECLARE followed by cursor name.
+func (p *Parser) declareCursor() *ast.DeclareCursorStatement {
+	stmt := ast.DeclareCursorStatement{}
+	p.next()
+	stmt.Name = p.word.Literal
+	p.next()
+
+	for p.word.Token == token.IDENT {
+		stmt.ISOOptions = append(stmt.ISOOptions, p.word.Literal)
+		p.next()
+	}
+	if p.word.Token == token.CURSOR {
+		p.next()
+	}
+	for p.word.Token == token.IDENT {
+		stmt.Options = append(stmt.Options, p.word.Literal)
+		p.next()
+	}
+
+	if p.word.Token == token.FOR {
+		p.next()
+	}
+	if p.word.Token == token.SELECT || p.isSelectWith() {
+		stmt.Query = p.selectStatement()
+	}
+	if p.word.Token == token.FOR {
+		p.next()
+		stmt.For = p.expression(func(ast.Word) bool {
+			return p.isColumnDefinitionEnd() && p.word.Token != token.COMMA
+		})
+	}
+	return &stmt
+}
+
+// Method cursorStatement parses OPEN, CLOSE and DEALLOCATE statements. This
+// method assumes that current word is one of these keywords.
+func (p *Parser) cursorStatement() *ast.CursorStatement {
+	stmt := ast.CursorStatement{}
+	switch p.word.Token {
+	case token.OPEN:
+		stmt.Kind = ast.OPENCURSOR
+	case token.CLOSE:
+		stmt.Kind = ast.CLOSECURSOR
+	case token.DEALLOCATE:
+		stmt.Kind = ast.DEALLOCATECURSOR
+	}
+	p.next()
+
+	stmt.Global, stmt.Cursor = p.cursorName()
+	return &stmt
+}
+
+// Method cursorName parses name of a cursor with optional GLOBAL keyword.
+func (p *Parser) cursorName() (bool, string) {
+	global := false
+	if p.isWord("GLOBAL") && p.peek().Token == token.IDENT {
+		global = true
+		p.next()
+	}
+	name := p.word.Literal
+	p.next()
+	return global, name
+}
+
+// Method fetchStatement parses FETCH statement. This method assumes that
+// current word is FETCH.
+func (p *Parser) fetchStatement() *ast.FetchStatement {
+	stmt := ast.FetchStatement{}
+	p.next()
+
+	if isFetchOrientation(p.word) {
+		stmt.Orientation = p.expression(func(w ast.Word) bool {
+			return w.Token == token.FROM || p.isColumnDefinitionEnd()
+		})
+	}
+	if p.word.Token == token.FROM {
+		stmt.From = true
+		p.next()
+	}
+	stmt.Global, stmt.Cursor = p.cursorName()
+
+	if p.word.Token == token.INTO {
+		p.next()
+		for {
+			stmt.Into = append(stmt.Into, p.word.Literal)
+			p.next()
+			if p.word.Token != token.COMMA {
+				break
+			}
+			p.next()
+		}
+	}
+	return &stmt
+}
+
+// Function isFetchOrientation checks if given word is orientation of FETCH
+// statement.
+func isFetchOrientation(w ast.Word) bool {
+	for _, orientation := range []string{"NEXT", "PRIOR", "FIRST", "LAST",
+		"ABSOLUTE", "RELATIVE"} {
+		if IsWord(w, orientation) {
+			return true
+		}
+	}
+	return false
+}
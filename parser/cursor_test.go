@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"testing"
+)
+
+// Test for parsing cursor declarations.
+func TestParseDeclareCursor(t *testing.T) {
+	p := testParser(`DECLARE c CURSOR LOCAL FAST_FORWARD FOR SELECT a FROM t
+	DECLARE c2 INSENSITIVE SCROLL CURSOR FOR SELECT a FROM t FOR READ ONLY;
+	DECLARE c3 CURSOR FOR SELECT a FROM t FOR UPDATE OF a, b
+	OPEN c3`)
+
+	cursor, isCursor := p.statement().(*ast.DeclareCursorStatement)
+	if !isCursor {
+		t.Fatalf("Expected cursor declaration")
+	}
+	if cursor.Name != "c" || len(cursor.ISOOptions) != 0 ||
+		len(cursor.Options) != 2 || cursor.Query == nil || cursor.For != nil {
+		t.Errorf("Unexpected cursor declaration: %v", cursor)
+	}
+
+	cursor = p.statement().(*ast.DeclareCursorStatement)
+	if len(cursor.ISOOptions) != 2 || len(cursor.Options) != 0 ||
+		len(cursor.For) != 2 || !cursor.Terminated() {
+		t.Errorf("Expected ISO cursor FOR READ ONLY, got: %v", cursor)
+	}
+	cursor = p.statement().(*ast.DeclareCursorStatement)
+	if len(cursor.For) != 5 {
+		t.Errorf("Expected FOR UPDATE OF a, b, got: %v", cursor.For)
+	}
+	if _, isOpen := p.statement().(*ast.CursorStatement); !isOpen {
+		t.Errorf("Expected OPEN statement after cursor declaration")
+	}
+}
+
+// Test for parsing OPEN, FETCH, CLOSE and DEALLOCATE statements.
+func TestParseCursorStatements(t *testing.T) {
+	p := testParser(`OPEN GLOBAL c
+	FETCH NEXT FROM c INTO @a, @b
+	FETCH ABSOLUTE -1 FROM @cursor
+	FETCH c
+	CLOSE c DEALLOCATE c
+	SELECT a FROM t ORDER BY a OFFSET 1 ROWS FETCH NEXT 5 ROWS ONLY`)
+
+	open, isOpen := p.statement().(*ast.CursorStatement)
+	if !isOpen || open.Kind != ast.OPENCURSOR || !open.Global ||
+		open.Cursor != "c" {
+		t.Errorf("Expected OPEN GLOBAL c, got: %v", open)
+	}
+
+	expOrientations := []int{1, 3, 0}
+	expInto := []int{2, 0, 0}
+	for id := range expOrientations {
+		fetch, isFetch := p.statement().(*ast.FetchStatement)
+		if !isFetch {
+			t.Fatalf("Expected FETCH statement %d", id)
+		}
+		if len(fetch.Orientation) != expOrientations[id] ||
+			len(fetch.Into) != expInto[id] || fetch.From != (id < 2) {
+			t.Errorf("Unexpected FETCH statement %d: %v", id, fetch)
+		}
+	}
+
+	for _, kind := range []ast.CursorStatementKind{ast.CLOSECURSOR,
+		ast.DEALLOCATECURSOR} {
+		stmt, isCursor := p.statement().(*ast.CursorStatement)
+		if !isCursor || stmt.Kind != kind || stmt.Cursor != "c" {
+			t.Errorf("Expected cursor statement %d, got: %v", kind, stmt)
+		}
+	}
+	query := p.statement().(*ast.SelectStatement).Query
+	if len(query.OrderBy.Offset) != 8 {
+		t.Errorf("Expected OFFSET ... FETCH, got: %v", query.OrderBy.Offset)
+	}
+}
@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+)
+
+// Method dropStatement parses DROP statement of tables, views, procedures,
+// functions, indexes and triggers. DROP statements of other objects are
+// parsed as ast.RawStatement. This method assumes that current word is DROP.
+func (p *Parser) dropStatement() ast.Statement {
+	switch p.peek().Token {
+	case token.TABLE, token.VIEW, token.PROC, token.PROCEDURE, token.FUNCTION,
+		token.INDEX, token.TRIGGER:
+	default:
+		return p.rawStatement()
+	}
+
+	drop := ast.DropStatement{}
+	p.next()
+	drop.Object = p.word.Literal
+	p.next()
+	if p.word.Token == token.IF && p.peek().Token == token.EXISTS {
+		drop.IfExists = true
+		p.next()
+		p.next()
+	}
+
+	for {
+		drop.Names = append(drop.Names, p.expression(func(w ast.Word) bool {
+			return w.Token == token.COMMA || p.isColumnDefinitionEnd()
+		}))
+		if p.word.Token != token.COMMA {
+			return &drop
+		}
+		p.next()
+	}
+}
@@ -0,0 +1,35 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"testing"
+)
+
+// Test for parsing DROP statements.
+func TestParseDrop(t *testing.T) {
+	p := testParser(`DROP TABLE IF EXISTS dbo.a, #b
+	DROP PROC dbo.p
+	DROP INDEX IF EXISTS ix ON dbo.t WITH (ONLINE = ON), ix2 ON dbo.u;
+	DROP USER u`)
+	expObjects := []string{"TABLE", "PROC", "INDEX"}
+	expNames := []int{2, 1, 2}
+
+	for id, object := range expObjects {
+		stmt := p.statement()
+		drop, isDrop := stmt.(*ast.DropStatement)
+		if !isDrop {
+			t.Fatalf("Expected DROP statement, got: %T", stmt)
+		}
+		if drop.Object != object || len(drop.Names) != expNames[id] {
+			t.Errorf("Expected DROP %s of %d objects, got: %v", object,
+				expNames[id], drop)
+		}
+		if drop.IfExists != (id != 1) {
+			t.Errorf("Unexpected IF EXISTS in DROP %s", object)
+		}
+	}
+
+	if _, isRaw := p.statement().(*ast.RawStatement); !isRaw {
+		t.Errorf("Expected DROP USER as raw statement")
+	}
+}
@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+)
+
+// Method execStatement parses EXEC or EXECUTE statement. EXECUTE AS statement
+// (context switching) is parsed as ast.RawStatement. This method assumes that
+// current word is EXEC or EXECUTE.
+func (p *Parser) execStatement() ast.Statement {
+	if p.peek().Token == token.AS {
+		return p.rawStatement()
+	}
+
+	stmt := ast.ExecStatement{Keyword: p.word.Literal}
+	p.next()
+	if p.word.Token == token.LPAREN {
+		stmt.Dynamic = true
+		p.next()
+		stmt.Args = p.execArguments()
+		if p.word.Token == token.RPAREN {
+			p.next()
+		}
+		p.execContext(&stmt)
+		return &stmt
+	}
+
+	if isVariable(p.word) && p.peek().Token == token.ASSIGN {
+		stmt.ReturnStatus = p.word.Literal
+		p.next()
+		p.next()
+	}
+	stmt.Name = p.objectName()
+	if p.word.Token == token.SEMICOLON && p.peek().Token == token.INT {
+		stmt.Name += p.word.Literal
+		p.next()
+		stmt.Name += p.word.Literal
+		p.next()
+	}
+
+	if !p.isColumnDefinitionEnd() && !p.isStatementStart() &&
+		p.word.Token != token.WITH {
+		stmt.Args = p.execArguments()
+	}
+	if p.word.Token == token.WITH {
+		p.next()
+		for {
+			stmt.Options = append(stmt.Options, p.expression(func(ast.Word) bool {
+				return p.isColumnDefinitionEnd() || p.isStatementStart()
+			}))
+			if p.word.Token != token.COMMA {
+				break
+			}
+			p.next()
+		}
+	}
+	return &stmt
+}
+
+// Method execArguments parses comma-separated list of EXEC arguments.
+func (p *Parser) execArguments() []*ast.ExecArgument {
+	args := make([]*ast.ExecArgument, 0, 5)
+
+	for {
+		arg := ast.ExecArgument{}
+		if isVariable(p.word) && p.peek().Token == token.ASSIGN {
+			arg.Name = p.word.Literal
+			p.next()
+			p.next()
+		}
+		arg.Value = p.expression(func(w ast.Word) bool {
+			return w.Token == token.WITH || isParameterOption(w) ||
+				p.isColumnDefinitionEnd()
+		})
+		if p.isWord("OUT") || p.isWord("OUTPUT") {
+			arg.Output = p.word.Literal
+			p.next()
+		}
+		arg.End = p.prev.Pos
+		args = append(args, &arg)
+
+		if p.word.Token != token.COMMA {
+			return args
+		}
+		p.next()
+	}
+}
+
+// Method execContext parses AS { LOGIN | USER } = 'name' and AT clauses of
+// EXEC statement with dynamic SQL.
+func (p *Parser) execContext(stmt *ast.ExecStatement) {
+	if p.word.Token == token.AS {
+		stmt.Context = ast.Expression{p.word}
+		p.next()
+		for i := 0; i < 3; i++ {
+			stmt.Context = append(stmt.Context, p.word)
+			p.next()
+		}
+	}
+	if p.isWord("AT") {
+		p.next()
+		stmt.At = ast.Expression{p.word}
+		p.next()
+		if IsWord(stmt.At[0], "DATA_SOURCE") {
+			stmt.At = append(stmt.At, p.word)
+			p.next()
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"testing"
+)
+
+// Test for parsing EXEC statements of stored procedures.
+func TestParseExec(t *testing.T) {
+	p := testParser(`EXEC @rc = dbo.p @a = 1, @b = @x OUTPUT, 'c' + @d
+	EXECUTE sp_executesql @sql, N'@p int', @p = 1
+	EXEC p;2 WITH RECOMPILE
+	EXEC p
+	SELECT 1`)
+
+	exec, isExec := p.statement().(*ast.ExecStatement)
+	if !isExec {
+		t.Fatalf("Expected EXEC statement")
+	}
+	if exec.Keyword != "EXEC" || exec.ReturnStatus != "@rc" ||
+		exec.Name != "dbo.p" || len(exec.Args) != 3 {
+		t.Errorf("Unexpected EXEC statement: %v", exec)
+	}
+	b, d := exec.Args[1], exec.Args[2]
+	if b.Name != "@b" || b.Output != "OUTPUT" || len(b.Value) != 1 {
+		t.Errorf("Expected @b = @x OUTPUT, got: %v", b)
+	}
+	if d.Name != "" || len(d.Value) != 3 {
+		t.Errorf("Expected positional argument 'c' + @d, got: %v", d)
+	}
+
+	exec = p.statement().(*ast.ExecStatement)
+	if exec.Keyword != "EXECUTE" || len(exec.Args) != 3 ||
+		exec.Args[0].Name != "" || exec.Args[2].Name != "@p" {
+		t.Errorf("Unexpected sp_executesql call: %v", exec)
+	}
+	exec = p.statement().(*ast.ExecStatement)
+	if exec.Name != "p;2" || exec.Args != nil || len(exec.Options) != 1 {
+		t.Errorf("Expected EXEC p;2 WITH RECOMPILE, got: %v", exec)
+	}
+	exec = p.statement().(*ast.ExecStatement)
+	if exec.Name != "p" || exec.Args != nil {
+		t.Errorf("Expected EXEC p without arguments, got: %v", exec)
+	}
+	if _, isSelect := p.statement().(*ast.SelectStatement); !isSelect {
+		t.Errorf("Expected SELECT statement after EXEC")
+	}
+}
+
+// Test for parsing EXEC statements with dynamic SQL and EXECUTE AS.
+func TestParseExecDynamic(t *testing.T) {
+	p := testParser(`EXEC (@sql + N'x')
+	EXEC ('SELECT ?', 1) AS USER = 'u' AT srv;
+	EXECUTE AS USER = 'u'
+	GRANT EXECUTE ON p TO u
+	EXEC p`)
+
+	exec, isExec := p.statement().(*ast.ExecStatement)
+	if !isExec || !exec.Dynamic || len(exec.Args) != 1 ||
+		len(exec.Args[0].Value) != 3 {
+		t.Fatalf("Expected EXEC with dynamic SQL, got: %v", exec)
+	}
+	exec = p.statement().(*ast.ExecStatement)
+	if len(exec.Args) != 2 || len(exec.Context) != 4 || len(exec.At) != 1 ||
+		!exec.Terminated() {
+		t.Errorf("Expected EXEC with AS USER and AT, got: %v", exec)
+	}
+	for _, exp := range []string{"EXECUTE", "GRANT"} {
+		raw, isRaw := p.statement().(*ast.RawStatement)
+		if !isRaw || raw.Words[0].Literal != exp {
+			t.Errorf("Expected raw %s statement, got: %v", exp, raw)
+		}
+	}
+	if _, isExec := p.statement().(*ast.ExecStatement); !isExec {
+		t.Errorf("Expected EXEC statement after GRANT")
+	}
+}
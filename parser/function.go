@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+)
+
+// Method function parses CREATE/ALTER FUNCTION statement. This method assumes
+// that CREATE, ALTER or CREATE OR ALTER was already parsed and current word is
+// FUNCTION.
+func (p *Parser) function(mode ast.CreateMode) *ast.FunctionStatement {
+	function := ast.FunctionStatement{Mode: mode}
+	p.next()
+	function.Name = p.objectName()
+
+	if p.word.Token == token.LPAREN {
+		p.next()
+	}
+	function.Parameters = p.parameterList()
+	if p.word.Token == token.RPAREN {
+		p.next()
+	}
+
+	if p.isWord("RETURNS") {
+		p.next()
+	}
+	p.functionReturns(&function)
+
+	if p.word.Token == token.WITH {
+		function.Options = p.optionList()
+	}
+	if p.word.Token == token.AS {
+		function.ASKeyword = true
+		p.next()
+	}
+
+	if function.Kind == ast.INLINETABLE && p.word.Token == token.RETURN {
+		ret := ast.ReturnStatement{}
+		first := p.word
+		p.next()
+		if !p.returnQuery(&ret) {
+			ret.Value = p.expression(func(w ast.Word) bool {
+				return w.Token == token.SEMICOLON
+			})
+		}
+		if p.word.Token == token.SEMICOLON {
+			ret.Terminate()
+			p.next()
+		}
+		ret.SetRange(first.Pos, p.prev.Pos)
+		function.Body = append(function.Body, &ret)
+	}
+	function.Body = append(function.Body,
+		p.statementList(func() bool { return false })...)
+
+	return &function
+}
+
+// Method returnQuery parses query returned by inline table-valued function,
+// which may be written in parentheses. The query has to end the function.
+// Otherwise false is returned and the Parser doesn't move.
+func (p *Parser) returnQuery(ret *ast.ReturnStatement) bool {
+	state := p.save()
+	parens := p.word.Token == token.LPAREN
+	if parens {
+		p.next()
+	}
+	if p.word.Token != token.SELECT && !p.isCTE() {
+		p.restore(state)
+		return false
+	}
+
+	query := p.selectStatement()
+	if parens && p.word.Token == token.RPAREN {
+		p.next()
+	} else if parens {
+		p.restore(state)
+		return false
+	}
+	switch p.word.Token {
+	case token.SEMICOLON, token.EOF, token.GO:
+		ret.Query, ret.Parens = query, parens
+		return true
+	}
+	p.restore(state)
+	return false
+}
+
+// Method functionReturns parses type of value returned by function - data
+// type, TABLE or @variable TABLE (...). This method assumes that RETURNS
+// keyword was already parsed.
+func (p *Parser) functionReturns(function *ast.FunctionStatement) {
+	switch {
+	case p.word.Token == token.TABLE:
+		function.Kind = ast.INLINETABLE
+		p.next()
+	case isVariable(p.word) && p.peek().Token == token.TABLE:
+		function.Kind = ast.MULTISTATEMENTTABLE
+		function.ReturnVariable = p.word.Literal
+		p.next()
+		p.next()
+		function.ReturnTable = p.tableDefinition()
+	default:
+		function.Kind = ast.SCALAR
+		function.ReturnType = p.dataType()
+	}
+}
+
+// Method returnStatement parses RETURN statement with optional value. Value
+// ends where a raw statement would end and its CASE expressions and OVER
+// clauses are structured. This method assumes that current word is RETURN.
+func (p *Parser) returnStatement() *ast.ReturnStatement {
+	ret := ast.ReturnStatement{}
+	first := p.word
+	p.next()
+
+	if p.word.Token == token.EOF || p.word.Token == token.GO ||
+		p.isRawStatementEnd(ast.Expression{first}) {
+		return &ret
+	}
+	ret.Value = structure(p.rawStatement().Words)
+	return &ret
+}
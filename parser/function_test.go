@@ -0,0 +1,141 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"testing"
+)
+
+// Test for parsing scalar function.
+func TestParseScalarFunction(t *testing.T) {
+	p := testParser(`CREATE FUNCTION dbo.fAdd (@a int, @b AS int = 1)
+	RETURNS decimal(10, 2)
+	WITH SCHEMABINDING, RETURNS NULL ON NULL INPUT
+	AS
+	BEGIN
+		RETURN @a + @b
+	END`)
+	function := parseFunction(t, p)
+
+	if function.Kind != ast.SCALAR || function.Name != "dbo.fAdd" {
+		t.Errorf("Expected scalar function dbo.fAdd, got: %d %s", function.Kind,
+			function.Name)
+	}
+	if len(function.Parameters) != 2 || !function.Parameters[1].ASKeyword {
+		t.Errorf("Expected 2 parameters, second with AS keyword")
+	}
+	if function.ReturnType.Name != "decimal" ||
+		len(function.ReturnType.Params) != 2 {
+		t.Errorf("Expected return type decimal(10, 2), got: %v",
+			function.ReturnType)
+	}
+	if len(function.Options) != 2 || len(function.Options[1]) != 5 {
+		t.Errorf("Expected 2 options, got: %v", function.Options)
+	}
+	if !function.ASKeyword || len(function.Body) != 1 {
+		t.Fatalf("Expected AS keyword and single statement body")
+	}
+
+	block := function.Body[0].(*ast.BlockStatement)
+	ret, isReturn := block.Statements[0].(*ast.ReturnStatement)
+	if !isReturn || len(ret.Value) != 3 {
+		t.Errorf("Expected [RETURN @a + @b], got: %v", block.Statements[0])
+	}
+}
+
+// Test for parsing inline table-valued function.
+func TestParseInlineFunction(t *testing.T) {
+	p := testParser(`CREATE OR ALTER FUNCTION dbo.fInline(@id int)
+	RETURNS TABLE
+	RETURN SELECT a, b FROM dbo.t WHERE id = @id;`)
+	function := parseFunction(t, p)
+
+	if function.Kind != ast.INLINETABLE || function.ASKeyword {
+		t.Errorf("Expected inline function without AS keyword")
+	}
+	if len(function.Body) != 1 {
+		t.Fatalf("Expected single statement body, got: %d", len(function.Body))
+	}
+	ret := function.Body[0].(*ast.ReturnStatement)
+	if ret.Query == nil || ret.Parens || len(ret.Value) != 0 {
+		t.Fatalf("Expected RETURN of query without parentheses, got: %v", ret)
+	}
+	if len(ret.Query.Query.Columns) != 2 || ret.Query.Query.Where == nil {
+		t.Errorf("Expected query with 2 columns and WHERE, got: %v",
+			ret.Query.Query)
+	}
+	if !ret.Terminated() {
+		t.Errorf("Expected RETURN terminated by semicolon")
+	}
+}
+
+// Test for parsing inline table-valued function returning query in
+// parentheses. Query combined with other words stays a flat value.
+func TestParseInlineFunctionParens(t *testing.T) {
+	p := testParser(`CREATE FUNCTION dbo.f() RETURNS TABLE
+	AS RETURN (SELECT a FROM t)
+	GO
+	CREATE FUNCTION dbo.g() RETURNS TABLE
+	AS RETURN (SELECT 1 AS a) UNION ALL (SELECT 2)`)
+	function := parseFunction(t, p)
+
+	ret := function.Body[0].(*ast.ReturnStatement)
+	if ret.Query == nil || !ret.Parens {
+		t.Errorf("Expected RETURN of query in parentheses, got: %v", ret)
+	}
+	p.next()
+	function = parseFunction(t, p)
+
+	ret = function.Body[0].(*ast.ReturnStatement)
+	if ret.Query != nil || len(ret.Value) != 12 {
+		t.Errorf("Expected RETURN with 12 words of value, got: %v", ret)
+	}
+}
+
+// Test for parsing multi-statement table-valued function.
+func TestParseMultiStatementFunction(t *testing.T) {
+	p := testParser(`ALTER FUNCTION dbo.fMulti()
+	RETURNS @t TABLE (id int NOT NULL, name nvarchar(10) DEFAULT ('x, y'))
+	AS BEGIN INSERT INTO @t SELECT 1, 'x' RETURN END`)
+	function := parseFunction(t, p)
+
+	if function.Kind != ast.MULTISTATEMENTTABLE || function.Mode != ast.ALTER {
+		t.Errorf("Expected ALTER of multi-statement function")
+	}
+	if len(function.Parameters) != 0 || function.ReturnVariable != "@t" {
+		t.Errorf("Expected no parameters and @t return variable")
+	}
+	elements := function.ReturnTable.Elements
+	if len(elements) != 2 {
+		t.Fatalf("Expected 2 table elements, got: %v", elements)
+	}
+	id := elements[0].(*ast.ColumnDefinition)
+	name := elements[1].(*ast.ColumnDefinition)
+	if len(id.Options) != 1 || id.Options[0].Kind != ast.NOTNULLOPTION {
+		t.Errorf("Expected NOT NULL column, got: %v", id.Options)
+	}
+	if len(name.Options) != 1 || len(name.Options[0].Constraint.Expr) != 3 {
+		t.Errorf("Expected DEFAULT ('x, y') column, got: %v", name.Options)
+	}
+
+	block := function.Body[0].(*ast.BlockStatement)
+	if len(block.Statements) != 2 {
+		t.Fatalf("Expected 2 statements in body, got: %d",
+			len(block.Statements))
+	}
+	ret := block.Statements[1].(*ast.ReturnStatement)
+	if ret.Value != nil {
+		t.Errorf("Expected RETURN without value, got: %v", ret.Value)
+	}
+}
+
+// Function parseFunction parses single statement and checks that it's a
+// function.
+func parseFunction(t *testing.T, p *Parser) *ast.FunctionStatement {
+	t.Helper()
+	stmt := p.statement()
+	function, isFunction := stmt.(*ast.FunctionStatement)
+	if !isFunction {
+		t.Fatalf("Expected function statement, got: %T", stmt)
+	}
+	return function
+}
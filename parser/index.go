@@ -0,0 +1,80 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+)
+
+// Method createIndex parses CREATE INDEX statement. This method assumes that
+// current word is CREATE and isIndex returned true.
+func (p *Parser) createIndex() *ast.CreateIndexStatement {
+	index := ast.CreateIndexStatement{}
+	p.next()
+	if p.word.Token == token.UNIQUE {
+		index.Unique = true
+		p.next()
+	}
+	if p.word.Token == token.CLUSTERED || p.word.Token == token.NONCLUSTERED {
+		index.Clustered = p.word.Literal
+		p.next()
+	}
+	if p.isWord("COLUMNSTORE") {
+		index.Columnstore = true
+		p.next()
+	}
+	p.next()
+	index.Name = p.word.Literal
+	p.next()
+
+	if p.word.Token == token.ON {
+		p.next()
+		index.Table = p.objectName()
+	}
+	if p.word.Token == token.LPAREN {
+		index.Columns = p.parenList()
+	}
+	if p.isWord("INCLUDE") && p.peek().Token == token.LPAREN {
+		p.next()
+		index.Include = p.nameList()
+	}
+	if p.word.Token == token.WHERE {
+		p.next()
+		index.Where = p.expression(func(w ast.Word) bool {
+			return w.Token == token.WITH || w.Token == token.ON ||
+				p.isColumnDefinitionEnd()
+		})
+	}
+	if p.word.Token == token.WITH && p.peek().Token == token.LPAREN {
+		p.next()
+		index.Options = p.parenList()
+	}
+	if p.word.Token == token.ON {
+		p.next()
+		index.FileGroup = ast.Expression{p.word}
+		p.next()
+		if p.word.Token == token.LPAREN {
+			index.FileGroup = append(index.FileGroup, p.parenthesized()...)
+		}
+	}
+	index.Rest = p.expression(func(ast.Word) bool {
+		return p.isColumnDefinitionEnd()
+	})
+	return &index
+}
+
+// Method isIndex checks if CREATE keyword (current word) starts CREATE INDEX
+// statement. Other kinds of indexes (like XML or SPATIAL) are not recognized.
+func (p *Parser) isIndex() bool {
+	n := 1
+	if p.peekN(n).Token == token.UNIQUE {
+		n++
+	}
+	if w := p.peekN(n); w.Token == token.CLUSTERED ||
+		w.Token == token.NONCLUSTERED {
+		n++
+	}
+	if IsWord(p.peekN(n), "COLUMNSTORE") {
+		n++
+	}
+	return p.peekN(n).Token == token.INDEX
+}
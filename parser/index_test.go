@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"testing"
+)
+
+// Test for parsing CREATE INDEX statements.
+func TestParseCreateIndex(t *testing.T) {
+	p := testParser(`CREATE UNIQUE NONCLUSTERED INDEX ix ON dbo.t (a ASC, b DESC)
+	INCLUDE (c, d) WHERE a IS NOT NULL AND b > 0
+	WITH (FILLFACTOR = 90, ONLINE = ON) ON [PRIMARY];
+	CREATE CLUSTERED COLUMNSTORE INDEX cci ON dbo.t ON ps (d)
+	CREATE INDEX ix2 ON t (a) WITH FILLFACTOR = 80
+	CREATE INDEX ix3 ON t (b)`)
+
+	index, isIndex := p.statement().(*ast.CreateIndexStatement)
+	if !isIndex {
+		t.Fatalf("Expected CREATE INDEX statement")
+	}
+	if !index.Unique || index.Clustered != "NONCLUSTERED" ||
+		index.Columnstore || index.Name != "ix" || index.Table != "dbo.t" {
+		t.Errorf("Unexpected index header: %v", index)
+	}
+	if len(index.Columns) != 2 || len(index.Columns[1]) != 2 {
+		t.Errorf("Expected 2 key columns, got: %v", index.Columns)
+	}
+	if len(index.Include) != 2 || len(index.Where) != 8 {
+		t.Errorf("Expected INCLUDE and WHERE, got: %v %v", index.Include,
+			index.Where)
+	}
+	if len(index.Options) != 2 || len(index.FileGroup) != 1 ||
+		!index.Terminated() {
+		t.Errorf("Expected WITH and ON clauses, got: %v %v", index.Options,
+			index.FileGroup)
+	}
+
+	index = p.statement().(*ast.CreateIndexStatement)
+	if !index.Columnstore || index.Clustered != "CLUSTERED" ||
+		index.Columns != nil || len(index.FileGroup) != 4 {
+		t.Errorf("Unexpected clustered columnstore index: %v", index)
+	}
+
+	index = p.statement().(*ast.CreateIndexStatement)
+	if index.Options != nil || len(index.Rest) != 4 {
+		t.Errorf("Expected deprecated WITH clause, got: %v", index.Rest)
+	}
+	if index = p.statement().(*ast.CreateIndexStatement); index.Name != "ix3" {
+		t.Errorf("Expected index ix3, got: %v", index.Name)
+	}
+}
@@ -4,12 +4,13 @@ import (
 	"mssfmt/ast"
 	"mssfmt/scanner"
 	"mssfmt/token"
+	"strings"
 )
 
 type Words []ast.Word
 
 // Function ScanWords scans till EOF and accumulates all SQL tokens and literals
-// as Words.
+// as Words. Offset of each Word position is its index in returned Words.
 func ScanWords(s scanner.Scanner) Words {
 	words := make(Words, 0, 1000)
 	for {
@@ -17,33 +18,78 @@ func ScanWords(s scanner.Scanner) Words {
 		if tok == token.EOF {
 			return words
 		}
-		words = append(words, ast.Word{tok, litt})
+		pos := s.Pos()
+		pos.Offset = len(words)
+		words = append(words, ast.Word{Token: tok, Literal: litt, Pos: pos})
 	}
 }
 
-// TODO
+// Parser keeps state of parsing pre-scanned Words of T-SQL script. Parsed
+// statements and ranges of batches are recorded for associating comments.
+// Positions of parsed aliases are recorded in aliases.
 type Parser struct {
-	fileName string
-	source   Words
-	word     ast.Word
-	offset   int
+	fileName   string
+	source     Words
+	word       ast.Word
+	prev       ast.Word
+	offset     int
+	statements []ast.Statement
+	batches    []nodeRange
+	aliases    []token.Position
 }
 
+// Type parserState keeps position of the Parser and the number of recorded
+// aliases. It's used for backtracking when a part of the script cannot be
+// parsed in the expected way.
+type parserState struct {
+	offset  int
+	word    ast.Word
+	prev    ast.Word
+	aliases int
+}
+
+// Method Init prepares Parser for parsing given Words. After Init current word
+// is the first non-comment Word of the script.
 func (p *Parser) Init(name string, src Words) {
 	p.fileName = name
 	p.source = src
-	p.word = src[0] // TODO
-	p.offset = 0
+	p.offset = -1
+	p.next()
+}
+
+// Method spelling returns words of the script which may be keywords in the
+// order of occurrence. Parts of multi-word keywords are separate words with
+// the same position. Identifiers which aren't delimited and aren't variables
+// are returned as well, because some keywords (like NOCOUNT) aren't tokens.
+func (p *Parser) spelling() []ast.Word {
+	spelling := make([]ast.Word, 0, len(p.source)/2)
+	for _, word := range p.source {
+		if !word.Token.IsKeyword() && (word.Token != token.IDENT ||
+			strings.ContainsAny(word.Literal[:1], `["@#`)) {
+			continue
+		}
+		for _, part := range strings.Fields(word.Literal) {
+			spelling = append(spelling,
+				ast.Word{Token: word.Token, Literal: part, Pos: word.Pos})
+		}
+	}
+	return spelling
 }
 
 // Method next jumps to next Word in the SQL script.
 func (p *Parser) next() {
-	if p.offset+1 == len(p.source) {
+	if p.offset+1 >= len(p.source) {
+		if p.word.Token != token.COMMENT {
+			p.prev = p.word
+		}
 		p.offset = len(p.source)
-		p.word = ast.Word{token.EOF, ""}
+		p.word = ast.Word{Token: token.EOF, Literal: ""}
 		return
 	}
 
+	if p.word.Token != token.COMMENT {
+		p.prev = p.word
+	}
 	p.offset++
 	p.word = p.source[p.offset]
 
@@ -54,20 +100,244 @@ func (p *Parser) next() {
 	}
 }
 
+// Method save returns current position of the Parser.
+func (p *Parser) save() parserState {
+	return parserState{offset: p.offset, word: p.word, prev: p.prev,
+		aliases: len(p.aliases)}
+}
+
+// Method restore moves the Parser back to the position returned by save.
+// Aliases recorded after that position are forgotten.
+func (p *Parser) restore(state parserState) {
+	p.offset = state.offset
+	p.word = state.word
+	p.prev = state.prev
+	p.aliases = p.aliases[:state.aliases]
+}
+
 // Method peek returns next Word in the script but don't move forward.
 // Peek peeks next non-comment token.
 func (p *Parser) peek() ast.Word {
+	return p.peekN(1)
+}
+
+// Method peekN returns n-th next non-comment Word in the script without moving
+// forward. Call peekN(1) is equivalent to peek().
+func (p *Parser) peekN(n int) ast.Word {
 	i := 1
 
 	for {
-		if p.offset+i == len(p.source) {
-			return ast.Word{token.EOF, ""}
+		if p.offset+i >= len(p.source) {
+			return ast.Word{Token: token.EOF, Literal: ""}
 		}
 		if p.source[p.offset+i].Token == token.COMMENT {
 			i++
 			continue
 		}
-		return p.source[p.offset+i]
+		if n == 1 {
+			return p.source[p.offset+i]
+		}
+		n--
+		i++
+	}
+}
+
+// Method isWord checks if current word is an identifier with given name. It's
+// used for T-SQL keywords which aren't tokens, like READONLY or OUTPUT.
+// Comparison is case insensitive.
+func (p *Parser) isWord(name string) bool {
+	return IsWord(p.word, name)
+}
+
+// Function IsWord checks if given Word is an identifier with given name.
+func IsWord(w ast.Word, name string) bool {
+	return w.Token == token.IDENT && strings.EqualFold(w.Literal, name)
+}
+
+// Method objectName parses multi-part object name (like schema.object or
+// server.database..object) and returns it as a single string. This method
+// assumes that current word is the first part of the name.
+func (p *Parser) objectName() string {
+	name := p.word.Literal
+	p.next()
+
+	for p.word.Token == token.PERIOD {
+		name += "."
+		p.next()
+		if p.word.Token != token.PERIOD {
+			name += p.word.Literal
+			p.next()
+		}
+	}
+	return name
+}
+
+// Method nameList parses comma-separated list of names in parentheses, like
+// column names "(Col1, Col2)". This method assumes that current word is "(".
+func (p *Parser) nameList() []string {
+	names := make([]string, 0, 5)
+	p.next()
+
+	for p.word.Token != token.RPAREN && p.word.Token != token.EOF {
+		if p.word.Token != token.COMMA {
+			names = append(names, p.word.Literal)
+		}
+		p.next()
+	}
+	p.next()
+
+	return names
+}
+
+// Method dataType parses T-SQL data type like INT, NVARCHAR(MAX),
+// DECIMAL(18, 2) or dbo.UserDefinedType.
+func (p *Parser) dataType() *ast.DataType {
+	dataType := ast.DataType{Name: p.objectName()}
+	if p.word.Token != token.LPAREN {
+		return &dataType
+	}
+
+	p.next()
+	for p.word.Token != token.RPAREN && p.word.Token != token.EOF {
+		if p.word.Token != token.COMMA {
+			dataType.Params = append(dataType.Params, p.word)
+		}
+		p.next()
+	}
+	p.next()
+
+	return &dataType
+}
+
+// Method expression parses T-SQL expression as a flat list of words. Parsing
+// stops on the first word (outside of parentheses and CASE expressions) for
+// which stop function returns true. CASE expressions and OVER clauses are
+// structured (see structure function).
+func (p *Parser) expression(stop func(ast.Word) bool) ast.Expression {
+	expr := make(ast.Expression, 0, 5)
+	depth := 0
+
+	for p.word.Token != token.EOF && p.word.Token != token.GO {
+		if depth == 0 && stop(p.word) {
+			break
+		}
+		switch p.word.Token {
+		case token.LPAREN, token.CASE:
+			depth++
+		case token.RPAREN, token.END:
+			depth--
+		}
+		if depth < 0 {
+			break
+		}
+		expr = append(expr, p.word)
+		p.next()
+	}
+	return structure(expr)
+}
+
+// Function structure keeps structure of each CASE expression of given flat
+// expression in its CASE keyword and window specification of each OVER
+// clause in its OVER keyword. Nested expressions are structured first, so
+// parts of enclosing structures contain their structure too. Expression is
+// modified in place and returned.
+func structure(expr ast.Expression) ast.Expression {
+	for id := len(expr) - 1; id >= 0; id-- {
+		switch {
+		case expr[id].Token == token.CASE && expr[id].Case == nil:
+			expr[id].Case = caseExpr(expr[id:])
+		case expr[id].Token == token.OVER && expr[id].Window == nil &&
+			id+1 < len(expr) && expr[id+1].Token == token.LPAREN:
+			var p Parser
+			p.Init("", Words(expr[id+1:]))
+			expr[id].Window = p.windowSpec()
+		}
+	}
+	return expr
+}
+
+// Method parenthesized parses expression in parentheses (including them) as a
+// flat list of words. CASE expressions and OVER clauses are structured as in
+// expression method. This method assumes that current word is "(".
+func (p *Parser) parenthesized() ast.Expression {
+	expr := ast.Expression{p.word}
+	depth := 1
+	p.next()
+
+	for depth > 0 && p.word.Token != token.EOF && p.word.Token != token.GO {
+		switch p.word.Token {
+		case token.LPAREN:
+			depth++
+		case token.RPAREN:
+			depth--
+		}
+		expr = append(expr, p.word)
+		p.next()
+	}
+	return structure(expr)
+}
+
+// Method parenList parses comma-separated list of expressions in parentheses,
+// like function arguments or table hints. Returned slice isn't nil even when
+// the list is empty. This method assumes that current word is "(".
+func (p *Parser) parenList() []ast.Expression {
+	list := make([]ast.Expression, 0, 3)
+	p.next()
+	if p.word.Token == token.RPAREN {
+		p.next()
+		return list
+	}
+
+	for {
+		list = append(list, p.expression(func(w ast.Word) bool {
+			return w.Token == token.COMMA
+		}))
+		if p.word.Token != token.COMMA {
+			break
+		}
+		p.next()
+	}
+	if p.word.Token == token.RPAREN {
+		p.next()
+	}
+	return list
+}
+
+// Method optionList parses comma-separated list of options after WITH keyword
+// in CREATE statements, like "WITH RECOMPILE, EXECUTE AS OWNER". Each option
+// is kept as an expression. List ends on AS keyword which is not part of
+// EXECUTE AS clause, on FOR, RETURN or BEGIN keyword or on AFTER and INSTEAD
+// in triggers. This method assumes that current word is WITH.
+func (p *Parser) optionList() []ast.Expression {
+	options := make([]ast.Expression, 0, 2)
+	p.next()
+
+	for {
+		option := make(ast.Expression, 0, 3)
+		prev := ast.Word{}
+		for !isOptionListEnd(p.word) &&
+			(p.word.Token != token.AS || IsWord(prev, "EXECUTE") ||
+				IsWord(prev, "EXEC")) {
+			option = append(option, p.word)
+			prev = p.word
+			p.next()
+		}
+		options = append(options, option)
+
+		if p.word.Token != token.COMMA {
+			return options
+		}
+		p.next()
+	}
+}
+
+// Function isOptionListEnd checks if given word ends option (or list of
+// options) after WITH keyword in CREATE statements.
+func isOptionListEnd(w ast.Word) bool {
+	switch w.Token {
+	case token.COMMA, token.FOR, token.RETURN, token.BEGIN, token.EOF,
+		token.GO:
+		return true
 	}
-	return ast.Word{token.EOF, ""}
+	return IsWord(w, "AFTER") || IsWord(w, "INSTEAD")
 }
@@ -88,3 +88,91 @@ func TestParsePeek(t *testing.T) {
 		t.Errorf("Expected token TOP, got: [%s]", peek.Literal)
 	}
 }
+
+// Test for Init method when the script starts with comments or is empty.
+func TestParserInit(t *testing.T) {
+	p1 := testParser("/* header */ -- comment\n SELECT 1")
+	p2 := testParser("")
+
+	if p1.word.Token != token.SELECT {
+		t.Errorf("Expected token SELECT, got: [%s]", p1.word.Token)
+	}
+	if p2.word.Token != token.EOF {
+		t.Errorf("Expected token EOF, got: [%s]", p2.word.Token)
+	}
+	p2.next()
+	if p2.word.Token != token.EOF {
+		t.Errorf("Expected token EOF after next, got: [%s]", p2.word.Token)
+	}
+}
+
+// Test for parsing multi-part object names.
+func TestParseObjectName(t *testing.T) {
+	srcs := []string{"tableName", "dbo.tableName", "[dbo].[table name]",
+		"db..tableName", "srv.db.dbo.t AS x"}
+	exp := []string{"tableName", "dbo.tableName", "[dbo].[table name]",
+		"db..tableName", "srv.db.dbo.t"}
+
+	for id, src := range srcs {
+		p := testParser(src)
+		name := p.objectName()
+		if name != exp[id] {
+			t.Errorf("Expected name [%s], got: [%s]", exp[id], name)
+		}
+	}
+}
+
+// Test for parsing data types.
+func TestParseDataType(t *testing.T) {
+	p1 := testParser("int")
+	p2 := testParser("nvarchar(max)")
+	p3 := testParser("DECIMAL(18, 2) NOT NULL")
+
+	dt1 := p1.dataType()
+	dt2 := p2.dataType()
+	dt3 := p3.dataType()
+
+	if dt1.Name != "int" || len(dt1.Params) != 0 {
+		t.Errorf("Expected [int] without params, got: [%s] %v", dt1.Name,
+			dt1.Params)
+	}
+	if dt2.Name != "nvarchar" || len(dt2.Params) != 1 ||
+		dt2.Params[0].Token != token.MAX {
+		t.Errorf("Expected [nvarchar(max)], got: [%s] %v", dt2.Name, dt2.Params)
+	}
+	if len(dt3.Params) != 2 || dt3.Params[1].Literal != "2" {
+		t.Errorf("Expected params (18, 2), got: %v", dt3.Params)
+	}
+	if p3.word.Token != token.NOT {
+		t.Errorf("Expected current token NOT, got: [%s]", p3.word.Token)
+	}
+}
+
+// Function testParser prepares Parser for given T-SQL source code.
+func testParser(src string) *Parser {
+	var s scanner.Scanner
+	var p Parser
+	s.Init("test", []byte(src))
+	p.Init("test", ScanWords(s))
+	return &p
+}
+
+// Test for recording spelling of each word which may be a keyword.
+func TestSpelling(t *testing.T) {
+	p := testParser("Select a From t order  By [From]; select NoCount, @v")
+	spelling := p.spelling()
+	exp := []string{"Select", "a", "From", "t", "order", "By", "select",
+		"NoCount"}
+	if len(spelling) != len(exp) {
+		t.Fatalf("Expected %d words, got: %v", len(exp), spelling)
+	}
+	for id, word := range spelling {
+		if word.Literal != exp[id] {
+			t.Errorf("Expected spelling [%s], got: [%s]", exp[id], word.Literal)
+		}
+	}
+	if spelling[4].Pos != spelling[5].Pos {
+		t.Errorf("Expected parts of ORDER BY at the same position, got: %v",
+			spelling[4:6])
+	}
+}
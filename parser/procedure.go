@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+)
+
+// Method procedure parses CREATE/ALTER PROCEDURE statement. This method
+// assumes that CREATE, ALTER or CREATE OR ALTER was already parsed and current
+// word is PROC or PROCEDURE.
+func (p *Parser) procedure(mode ast.CreateMode) *ast.ProcedureStatement {
+	proc := ast.ProcedureStatement{Mode: mode}
+	proc.Proc = p.word.Token == token.PROC
+	p.next()
+	proc.Name = p.objectName()
+
+	if p.word.Token == token.LPAREN {
+		proc.Parens = true
+		p.next()
+	}
+	proc.Parameters = p.parameterList()
+	if proc.Parens && p.word.Token == token.RPAREN {
+		p.next()
+	}
+
+	if p.word.Token == token.WITH {
+		proc.Options = p.optionList()
+	}
+	if p.word.Token == token.FOR && IsWord(p.peek(), "REPLICATION") {
+		proc.ForReplication = true
+		p.next()
+		p.next()
+	}
+	if p.word.Token == token.AS {
+		p.next()
+	}
+
+	proc.Body = p.statementList(func() bool { return false })
+	return &proc
+}
+
+// Method parameterList parses comma-separated list of procedure or function
+// parameters. List can be empty.
+func (p *Parser) parameterList() []*ast.Parameter {
+	params := make([]*ast.Parameter, 0, 5)
+
+	for isVariable(p.word) {
+		params = append(params, p.parameter())
+		if p.word.Token != token.COMMA {
+			break
+		}
+		p.next()
+	}
+	return params
+}
+
+// Method parameter parses single parameter definition of the form
+// "@name [AS] data_type [VARYING] [= default] [OUT | OUTPUT] [READONLY]".
+func (p *Parser) parameter() *ast.Parameter {
+	param := ast.Parameter{Name: p.word.Literal}
+	p.next()
+
+	if p.word.Token == token.AS {
+		param.ASKeyword = true
+		p.next()
+	}
+	param.Type = p.dataType()
+
+	if p.isWord("VARYING") {
+		param.Varying = true
+		p.next()
+	}
+	if p.word.Token == token.ASSIGN {
+		p.next()
+		param.Default = p.expression(func(w ast.Word) bool {
+			return w.Token == token.COMMA || w.Token == token.RPAREN ||
+				w.Token == token.AS || w.Token == token.WITH ||
+				w.Token == token.FOR || isParameterOption(w)
+		})
+	}
+	if p.isWord("OUT") || p.isWord("OUTPUT") {
+		param.Output = p.word.Literal
+		p.next()
+	}
+	if p.isWord("READONLY") {
+		param.ReadOnly = true
+		p.next()
+	}
+	param.End = p.prev.Pos
+	return &param
+}
+
+// Function isParameterOption checks if given word is one of keywords which
+// can be placed after parameter default value.
+func isParameterOption(w ast.Word) bool {
+	return IsWord(w, "OUT") || IsWord(w, "OUTPUT") || IsWord(w, "READONLY")
+}
+
+// Function isVariable checks if given word is T-SQL variable (@name).
+func isVariable(w ast.Word) bool {
+	return w.Token == token.IDENT && len(w.Literal) > 1 && w.Literal[0] == '@'
+}
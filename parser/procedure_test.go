@@ -0,0 +1,92 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+	"testing"
+)
+
+// Test for parsing CREATE PROCEDURE statement with parameters, options and
+// body.
+func TestParseProcedure(t *testing.T) {
+	p := testParser(`CREATE OR ALTER PROC dbo.usp_Test
+		@p1 int = 0 OUTPUT, @p2 dbo.TVP READONLY, @p3 AS nvarchar(max) = N'x'
+		WITH EXECUTE AS OWNER, RECOMPILE
+	AS
+	BEGIN
+		SELECT 1
+	END`)
+	stmt := p.statement()
+
+	proc, isProc := stmt.(*ast.ProcedureStatement)
+	if !isProc {
+		t.Fatalf("Expected procedure statement, got: %T", stmt)
+	}
+	if proc.Mode != ast.CREATEORALTER || !proc.Proc || proc.Parens {
+		t.Errorf("Expected CREATE OR ALTER PROC without parentheses")
+	}
+	if proc.Name != "dbo.usp_Test" {
+		t.Errorf("Expected name [dbo.usp_Test], got: [%s]", proc.Name)
+	}
+	if len(proc.Parameters) != 3 {
+		t.Fatalf("Expected 3 parameters, got: %d", len(proc.Parameters))
+	}
+
+	p1, p2, p3 := proc.Parameters[0], proc.Parameters[1], proc.Parameters[2]
+	if p1.Name != "@p1" || p1.Type.Name != "int" || p1.Output != "OUTPUT" ||
+		len(p1.Default) != 1 || p1.Default[0].Literal != "0" {
+		t.Errorf("Expected [@p1 int = 0 OUTPUT], got: %v", p1)
+	}
+	if p2.Type.Name != "dbo.TVP" || !p2.ReadOnly || p2.Default != nil {
+		t.Errorf("Expected [@p2 dbo.TVP READONLY], got: %v", p2)
+	}
+	if !p3.ASKeyword || p3.Type.Params[0].Token != token.MAX ||
+		p3.Default[0].Literal != "N'x'" {
+		t.Errorf("Expected [@p3 AS nvarchar(max) = N'x'], got: %v", p3)
+	}
+	if p1.End.String() != "test:2:15" || p2.End.String() != "test:2:35" {
+		t.Errorf("Expected parameters ending at 2:15 and 2:35, got: %s and %s",
+			p1.End, p2.End)
+	}
+
+	if len(proc.Options) != 2 || len(proc.Options[0]) != 3 ||
+		len(proc.Options[1]) != 1 {
+		t.Errorf("Expected options [EXECUTE AS OWNER] and [RECOMPILE], got: %v",
+			proc.Options)
+	}
+	if len(proc.Body) != 1 {
+		t.Fatalf("Expected single statement in body, got: %d", len(proc.Body))
+	}
+	if _, isBlock := proc.Body[0].(*ast.BlockStatement); !isBlock {
+		t.Errorf("Expected BEGIN ... END body, got: %T", proc.Body[0])
+	}
+}
+
+// Test for parsing ALTER PROCEDURE with parameters in parentheses and body
+// without BEGIN ... END which lasts till the end of the batch.
+func TestParseProcedureParens(t *testing.T) {
+	p := testParser(`ALTER PROCEDURE [dbo].[x] (@a int, @b varchar(10) = NULL OUT)
+	AS SET NOCOUNT ON; SELECT @a
+	GO
+	SELECT 2`)
+	script := p.Script()
+
+	if len(script.Batches) != 2 {
+		t.Fatalf("Expected 2 batches, got: %d", len(script.Batches))
+	}
+	proc, isProc := script.Batches[0].Statements[0].(*ast.ProcedureStatement)
+	if !isProc {
+		t.Fatalf("Expected procedure statement, got: %T",
+			script.Batches[0].Statements[0])
+	}
+	if proc.Mode != ast.ALTER || proc.Proc || !proc.Parens {
+		t.Errorf("Expected ALTER PROCEDURE with parentheses")
+	}
+	if len(proc.Parameters) != 2 || proc.Parameters[1].Output != "OUT" ||
+		proc.Parameters[1].Default[0].Token != token.NULL {
+		t.Errorf("Expected second parameter [@b varchar(10) = NULL OUT]")
+	}
+	if len(proc.Body) != 2 {
+		t.Errorf("Expected 2 statements in body, got: %d", len(proc.Body))
+	}
+}
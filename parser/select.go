@@ -1,11 +1,119 @@
 package parser
 
 import (
-	"fmt"
 	"mssfmt/ast"
 	"mssfmt/token"
+	"strings"
 )
 
+// Method selectStatement parses SELECT statement with optional common table
+// expressions and queries combined by set operators. This method assumes that
+// current word is SELECT or WITH which starts common table expressions.
+func (p *Parser) selectStatement() *ast.SelectStatement {
+	stmt := ast.SelectStatement{}
+	if p.word.Token == token.WITH {
+		stmt.With = p.commonTableExprs()
+	}
+
+	p.next()
+	stmt.Query = p.SelectQuery()
+
+	for p.isSetOperator() {
+		setOp := ast.SetOperation{Operator: p.word.Token}
+		p.next()
+		if p.word.Token == token.ALL {
+			setOp.All = true
+			p.next()
+		}
+		p.next()
+		setOp.Query = p.SelectQuery()
+		stmt.SetOps = append(stmt.SetOps, &setOp)
+	}
+	return &stmt
+}
+
+// Method isSetOperator checks if current word is UNION [ALL], EXCEPT or
+// INTERSECT operator followed by SELECT query.
+func (p *Parser) isSetOperator() bool {
+	switch p.word.Token {
+	case token.UNION, token.EXCEPT, token.INTERSECT:
+	default:
+		return false
+	}
+
+	next := p.peek()
+	if next.Token == token.ALL {
+		next = p.peekN(2)
+	}
+	return next.Token == token.SELECT
+}
+
+// Method commonTableExprs parses WITH clause of common table expressions.
+// This method assumes that current word is WITH and that isSelectWith returns
+// true.
+func (p *Parser) commonTableExprs() []*ast.CommonTableExpr {
+	ctes := make([]*ast.CommonTableExpr, 0, 2)
+	p.next()
+
+	for {
+		cte := ast.CommonTableExpr{Name: p.word.Literal}
+		p.next()
+		if p.word.Token == token.LPAREN {
+			cte.Columns = p.nameList()
+		}
+		p.next()
+		p.next()
+		cte.Query = p.selectStatement()
+		p.next()
+		ctes = append(ctes, &cte)
+
+		if p.word.Token != token.COMMA {
+			return ctes
+		}
+		p.next()
+	}
+}
+
+// Method isCTE checks if current word is WITH which starts common table
+// expressions, like "WITH cte AS (" or "WITH cte (Col1) AS (".
+func (p *Parser) isCTE() bool {
+	return p.word.Token == token.WITH && p.peek().Token == token.IDENT &&
+		(p.peekN(2).Token == token.LPAREN ||
+			p.peekN(2).Token == token.AS && p.peekN(3).Token == token.LPAREN)
+}
+
+// Method isSelectWith checks if current word is WITH which starts common
+// table expressions of SELECT statement. Each common table expression has to
+// be a SELECT query as well. Common table expressions used by other
+// statements (like UPDATE or MERGE) are parsed as ast.RawStatement.
+func (p *Parser) isSelectWith() bool {
+	if !p.isCTE() || IsWord(p.peek(), "XMLNAMESPACES") {
+		return false
+	}
+
+	depth := 0
+	prev := p.word
+	for i := 1; ; i++ {
+		w := p.peekN(i)
+		switch {
+		case w.Token == token.EOF || w.Token == token.GO:
+			return false
+		case w.Token == token.LPAREN:
+			if depth == 0 && prev.Token == token.AS &&
+				p.peekN(i+1).Token != token.SELECT {
+				return false
+			}
+			depth++
+		case w.Token == token.RPAREN:
+			depth--
+		case depth == 0 && w.Token != token.IDENT && w.Token != token.AS &&
+			w.Token != token.COMMA:
+			return w.Token == token.SELECT
+		}
+		prev = w
+	}
+}
+
 // Method SelectQuery parse SELECT query. This method assumes that token SELECT
 // was already parsed.
 func (p *Parser) SelectQuery() *ast.SelectQuery {
@@ -16,7 +124,13 @@ func (p *Parser) SelectQuery() *ast.SelectQuery {
 	p.selectColList(&selectTree)
 	p.selectInto(&selectTree)
 	p.selectFrom(&selectTree)
-	// ...
+	p.selectWhere(&selectTree)
+	p.selectGroupBy(&selectTree)
+	p.selectHaving(&selectTree)
+	p.selectWindow(&selectTree)
+	p.selectOrderBy(&selectTree)
+	p.selectFor(&selectTree)
+	p.selectOptions(&selectTree)
 
 	return &selectTree
 }
@@ -29,12 +143,12 @@ func (p *Parser) selectDistinct(selectTree *ast.SelectQuery) {
 
 	if p.word.Token == token.DISTINCT {
 		p.next()
-		(*selectTree).DistinctType = &ast.DistinctType{false, true}
+		(*selectTree).DistinctType = &ast.DistinctType{All: false, Distinct: true}
 		return
 	}
 	if p.word.Token == token.ALL {
 		p.next()
-		(*selectTree).DistinctType = &ast.DistinctType{true, false}
+		(*selectTree).DistinctType = &ast.DistinctType{All: true, Distinct: false}
 		return
 	}
 	(*selectTree).DistinctType = nil
@@ -55,25 +169,13 @@ func (p *Parser) selectTop(selectTree *ast.SelectQuery) {
 	top := ast.TopClause{}
 	tok := p.word.Token
 
-	if (tok == token.INT || tok == token.FLOAT) && tok != token.LPAREN {
+	if tok == token.INT || tok == token.FLOAT || tok == token.IDENT {
 		top.Expr = ast.Expression{p.word}
 		p.next()
 	}
 
 	if tok == token.LPAREN {
-		expr := make(ast.Expression, 0, 10)
-
-		for {
-			if p.word.Token == token.RPAREN {
-				expr = append(expr, p.word)
-				break
-			}
-
-			expr = append(expr, p.word)
-			p.next()
-		}
-		top.Expr = expr
-		p.next()
+		top.Expr = p.parenthesized()
 	}
 
 	if p.word.Token == token.PERCENT {
@@ -83,6 +185,7 @@ func (p *Parser) selectTop(selectTree *ast.SelectQuery) {
 	if p.word.Token == token.WITH && p.peek().Token == token.TIES {
 		top.WithTiesParam = true
 		p.next()
+		p.next()
 	}
 
 	(*selectTree).Top = &top
@@ -95,30 +198,57 @@ func (p *Parser) selectTop(selectTree *ast.SelectQuery) {
 // column list exists but mssfmt assumes that given T-SQL code is a valid code.
 // Therefore validation of all those details isn't necessary.
 func (p *Parser) selectColList(selectTree *ast.SelectQuery) {
-	stopTokens := colListStopTokens()
 	cols := make([]ast.Expression, 0, 10)
-	currCol := make(ast.Expression, 0, 3)
 
 	for {
-		if _, stop := stopTokens[p.word.Token]; stop {
-			cols = append(cols, currCol)
-			break
+		col := p.expression(func(w ast.Word) bool {
+			return w.Token == token.COMMA || p.isClauseEnd()
+		})
+		if alias := columnAlias(col); alias >= 0 {
+			p.aliases = append(p.aliases, col[alias].Pos)
 		}
-		if p.word.Token == token.COMMA {
-			cols = append(cols, currCol)
-			currCol = make(ast.Expression, 0, 3)
-			p.next()
-			continue
+		cols = append(cols, col)
+		if p.word.Token != token.COMMA {
+			break
 		}
-		currCol = append(currCol, p.word)
 		p.next()
 	}
 
 	(*selectTree).Columns = cols
 }
 
+// Function columnAlias returns index of the alias of SELECT column given by
+// an identifier at the end of the column, optionally preceded by AS. It
+// returns -1 when the column has no such alias. Identifier which follows
+// another operand is an alias, except time zone of AT TIME ZONE.
+func columnAlias(col ast.Expression) int {
+	n := len(col)
+	if n < 2 || col[n-1].Token != token.IDENT ||
+		strings.HasPrefix(col[n-1].Literal, "@") {
+		return -1
+	}
+	if col[n-2].Token == token.AS {
+		if n < 3 {
+			return -1
+		}
+		return n - 1
+	}
+	switch col[n-2].Token {
+	case token.IDENT, token.INT, token.FLOAT, token.STRING, token.RPAREN,
+		token.END, token.NULL:
+	default:
+		return -1
+	}
+	if n > 2 && strings.EqualFold(col[n-3].Literal, "TIME") &&
+		strings.EqualFold(col[n-2].Literal, "ZONE") {
+		return -1
+	}
+	return n - 1
+}
+
 // The following function returns dict of Tokens which should state that this is
-// end of list of columns in SELECT query.
+// end of list of columns in SELECT query or end of any other clause of SELECT
+// query.
 func colListStopTokens() map[token.Token]bool {
 	colStop := make(map[token.Token]bool)
 	colStop[token.FROM] = true
@@ -127,13 +257,61 @@ func colListStopTokens() map[token.Token]bool {
 	colStop[token.SELECT] = true
 	colStop[token.UPDATE] = true
 	colStop[token.INSERT] = true
+	colStop[token.DELETE] = true
 	colStop[token.TRUNCATE] = true
 	colStop[token.GO] = true
-	//colStop[token.LPAREN] = true
+	colStop[token.WHERE] = true
+	colStop[token.GROUPBY] = true
+	colStop[token.HAVING] = true
+	colStop[token.ORDERBY] = true
+	colStop[token.OPTION] = true
+	colStop[token.UNION] = true
+	colStop[token.EXCEPT] = true
+	colStop[token.INTERSECT] = true
+	colStop[token.WITH] = true
+	colStop[token.END] = true
 
 	return colStop
 }
 
+var clauseStopTokens = colListStopTokens()
+
+// Method isClauseEnd checks if current word ends a clause of SELECT query.
+// Words which start a new statement (like SET or PRINT) end the clause only
+// when previous word completes an expression, so they still can be used as
+// column names. FOR UPDATE and FOR READ ONLY options of cursor declaration end
+// the query as well as FOR XML or FOR JSON clause.
+func (p *Parser) isClauseEnd() bool {
+	if clauseStopTokens[p.word.Token] || p.isWindowClause() ||
+		p.isForClause() {
+		return true
+	}
+	if p.word.Token == token.FOR &&
+		(p.peek().Token == token.UPDATE || IsWord(p.peek(), "READ")) {
+		return true
+	}
+	return IsOperand(p.prev) && (p.isStatementStart() || p.isQueryStart())
+}
+
+// Method isQueryStart checks if current word is "(" followed by SELECT. After
+// a complete expression or table source it starts a new statement, like
+// "(SELECT 2)" in "SELECT 1 (SELECT 2)".
+func (p *Parser) isQueryStart() bool {
+	return p.word.Token == token.LPAREN && p.peek().Token == token.SELECT
+}
+
+// Function IsOperand checks if given word may be the last word of an
+// expression. Keywords which aren't reserved (like COUNT) may be names of
+// columns, so they are operands as well as DEFAULT value of an argument.
+func IsOperand(w ast.Word) bool {
+	switch w.Token {
+	case token.IDENT, token.INT, token.FLOAT, token.STRING, token.RPAREN,
+		token.END, token.NULL, token.ASC, token.DESC, token.DEFAULT:
+		return true
+	}
+	return w.Token.IsKeyword() && !w.Token.IsReserved()
+}
+
 // Method selectInto parses INTO expression in SELECT query.
 func (p *Parser) selectInto(selectTree *ast.SelectQuery) {
 	if p.word.Token != token.INTO {
@@ -147,11 +325,13 @@ func (p *Parser) selectInto(selectTree *ast.SelectQuery) {
 		return
 	}
 
-	intoLit := p.word.Literal
+	intoLit := p.objectName()
 	(*selectTree).Into = &intoLit
 }
 
-// Method for parsing FROM clause in SELECT query.
+// Method for parsing FROM clause in SELECT query. FROM clause consists of the
+// first table source and all of following joins and comma-separated table
+// sources.
 func (p *Parser) selectFrom(selectTree *ast.SelectQuery) {
 	if p.word.Token != token.FROM {
 		(*selectTree).From = nil
@@ -159,65 +339,431 @@ func (p *Parser) selectFrom(selectTree *ast.SelectQuery) {
 	}
 
 	p.next()
-
-	// parsing subquery
-	if p.word.Token == token.LPAREN {
-		fmt.Println("TODO(parser.selectFrom): Parsing subquery not implemented")
-		return
-	}
-
-	// parsing table name
-	if p.word.Token == token.IDENT {
-		p.tableName(selectTree)
-		p.next()
-	}
+	from := ast.FromClause{TableOrViewName: p.tableName()}
 
 	// parsing all JOIN expressions
-	for {
-		if !p.word.Token.IsJoinType() {
-			break
-			// TODO
-		}
-		p.joinClause(selectTree)
-		p.next()
+	for p.isJoinStart() {
+		from.Joins = append(from.Joins, p.joinClause())
 	}
+	(*selectTree).From = &from
 }
 
-// Method tableName parses table or view name just after FROM keyword in SELECT
-// query in case when token after FROM is an identifier.
-func (p *Parser) tableName(selectTree *ast.SelectQuery) {
+// Method tableName parses single table source in FROM clause - table or view
+// name, table-valued function call, derived table or other source in
+// parentheses - together with its alias, sample clause, table hints and PIVOT
+// or UNPIVOT operators.
+func (p *Parser) tableName() *ast.TableName {
 	tabName := ast.TableName{}
-	tabName.Name = p.word.Literal
 
-	p.next()
+	switch {
+	case p.word.Token == token.LPAREN && p.isSubquery():
+		state := p.save()
+		p.next()
+		tabName.Subquery = p.selectStatement()
+		if p.word.Token == token.RPAREN {
+			p.next()
+		} else {
+			p.restore(state)
+			tabName.Subquery = nil
+			tabName.Source = p.parenthesized()
+		}
+	case p.word.Token == token.LPAREN:
+		tabName.Source = p.parenthesized()
+	default:
+		tabName.Name = p.objectName()
+		if p.word.Token == token.LPAREN && !p.isQueryStart() {
+			tabName.Args = p.parenList()
+		}
+		if tabName.Args != nil && isRowsetFunction(tabName.Name) &&
+			p.word.Token == token.WITH && p.peek().Token == token.LPAREN {
+			p.next()
+			tabName.Schema = p.parenList()
+		}
+		if p.word.Token == token.FOR && IsWord(p.peek(), "SYSTEM_TIME") {
+			tabName.SystemTime = p.systemTime()
+		}
+	}
+
 	tabName.ASKeyword = p.word.Token == token.AS
 	if tabName.ASKeyword {
 		p.next()
 	}
-	if p.word.Token == token.IDENT {
+	if tabName.ASKeyword || p.isTableAlias() {
 		alias := p.word.Literal
 		tabName.Alias = &alias
+		p.aliases = append(p.aliases, p.word.Pos)
 		p.next()
 	}
+	if p.word.Token == token.LPAREN && !p.isQueryStart() &&
+		(tabName.Name == "" || tabName.Args != nil) {
+		tabName.Columns = p.nameList()
+	}
 
 	if p.word.Token == token.TABLESAMPLE {
-		// TODO: make routine for parsing table sample clause
-		// just after implementing ast.FromClause.Joins parsing
+		tabName.Sample = p.tableSample()
 	}
 
-	if p.word.Token == token.WITH {
-		// TODO: make routine for parsing table hints
-		// just after implementing ast.FromClause.Joins parsing
+	if p.word.Token == token.WITH && p.peek().Token == token.LPAREN {
+		p.next()
+		tabName.Hints = &ast.TableHints{Hints: p.parenList()}
 	}
 
-	from := ast.FromClause{&tabName, nil}
-	(*selectTree).From = &from
+	for (p.isWord("PIVOT") || p.isWord("UNPIVOT")) &&
+		p.peek().Token == token.LPAREN {
+		tabName.Pivots = append(tabName.Pivots, p.pivotClause())
+	}
+
+	tabName.End = p.prev.Pos
+	return &tabName
+}
+
+// Function isRowsetFunction checks if given name is OPENJSON or OPENXML
+// function, which may be followed by WITH clause with column definitions.
+func isRowsetFunction(name string) bool {
+	return strings.EqualFold(name, "OPENJSON") ||
+		strings.EqualFold(name, "OPENXML")
+}
+
+// Method pivotClause parses PIVOT or UNPIVOT operator with its alias. This
+// method assumes that current word is PIVOT or UNPIVOT.
+func (p *Parser) pivotClause() *ast.PivotClause {
+	pivot := ast.PivotClause{Unpivot: p.isWord("UNPIVOT")}
+	p.next()
+	p.next()
+
+	pivot.Value = p.expression(func(w ast.Word) bool {
+		return w.Token == token.FOR
+	})
+	if p.word.Token == token.FOR {
+		p.next()
+		pivot.For = p.objectName()
+	}
+	if p.word.Token == token.IN {
+		p.next()
+		if p.word.Token == token.LPAREN {
+			pivot.In = p.nameList()
+		}
+	}
+	if p.word.Token == token.RPAREN {
+		p.next()
+	}
+
+	pivot.ASKeyword = p.word.Token == token.AS
+	if pivot.ASKeyword {
+		p.next()
+	}
+	if pivot.ASKeyword || p.isTableAlias() {
+		pivot.Alias = p.word.Literal
+		p.aliases = append(p.aliases, p.word.Pos)
+		p.next()
+	}
+	return &pivot
+}
+
+// Method systemTime parses FOR SYSTEM_TIME clause of temporal table. Points
+// in time are date and time literals or variables, so each of them is a
+// single word. This method assumes that current word is FOR.
+func (p *Parser) systemTime() *ast.SystemTimeClause {
+	clause := ast.SystemTimeClause{}
+	p.next()
+	p.next()
+
+	switch {
+	case p.word.Token == token.AS:
+		clause.Kind = ast.ASOF
+		p.next()
+		p.next()
+		clause.Start = p.singleWord()
+	case p.word.Token == token.FROM || p.word.Token == token.BETWEEN:
+		clause.Kind = ast.FROMTO
+		if p.word.Token == token.BETWEEN {
+			clause.Kind = ast.BETWEENAND
+		}
+		p.next()
+		clause.Start = p.singleWord()
+		p.next()
+		clause.End = p.singleWord()
+	case p.isWord("CONTAINED"):
+		clause.Kind = ast.CONTAINEDIN
+		p.next()
+		p.next()
+		if p.word.Token == token.LPAREN {
+			p.next()
+			clause.Start = p.singleWord()
+			p.next()
+			clause.End = p.singleWord()
+			p.next()
+		}
+	case p.word.Token == token.ALL:
+		clause.Kind = ast.ALLTIME
+		p.next()
+	}
+	return &clause
+}
+
+// Method singleWord returns current word as an expression and moves to the
+// next word.
+func (p *Parser) singleWord() ast.Expression {
+	expr := ast.Expression{p.word}
+	p.next()
+	return expr
+}
+
+// Method isSubquery checks if current "(" starts a subquery.
+func (p *Parser) isSubquery() bool {
+	next := p.peek()
+	return next.Token == token.SELECT ||
+		(next.Token == token.WITH && p.peekN(2).Token == token.IDENT)
+}
+
+// Method isTableAlias checks if current word is an alias of table source
+// given without AS keyword.
+func (p *Parser) isTableAlias() bool {
+	return p.word.Token == token.IDENT && !p.isStatementStart() &&
+		!p.isWord("PIVOT") && !p.isWord("UNPIVOT") && !p.isWindowClause()
+}
+
+// Method tableSample parses TABLESAMPLE clause of table source. This method
+// assumes that current word is TABLESAMPLE.
+func (p *Parser) tableSample() *ast.TableSampleClause {
+	sample := ast.TableSampleClause{}
+	p.next()
+	if p.isWord("SYSTEM") {
+		sample.System = true
+		p.next()
+	}
+
+	p.next()
+	sample.SampleNumber = p.expression(func(w ast.Word) bool {
+		return w.Token == token.PERCENT || w.Token == token.ROWS
+	})
+	if p.word.Token == token.PERCENT {
+		sample.Perc = true
+		p.next()
+	}
+	if p.word.Token == token.ROWS {
+		sample.Rows = true
+		p.next()
+	}
+	p.next()
+
+	if p.word.Token == token.REPEATABLE {
+		sample.Repeatable = true
+		p.next()
+		p.next()
+		sample.RepSeed = p.expression(func(ast.Word) bool { return false })
+		p.next()
+	}
+	return &sample
+}
+
+// Method isJoinStart checks if current word starts next JOIN expression or
+// next comma-separated table source in FROM clause. LEFT and RIGHT followed
+// by "(" are functions rather than join types.
+func (p *Parser) isJoinStart() bool {
+	switch p.word.Token {
+	case token.JOIN, token.COMMA:
+		return true
+	case token.CROSS, token.OUTER:
+		next := p.peek()
+		return next.Token == token.JOIN || IsWord(next, "APPLY")
+	case token.INNER, token.LEFT, token.RIGHT, token.FULL:
+		return p.peek().Token != token.LPAREN
+	}
+	return false
 }
 
 // Method joinClause parses single JOIN expression in T-SQL query. This method
 // is meant to be called until all of JOIN expressions from the SELECT are
 // parsed. When method is called current token supposed to be SQL JOIN type
 // keyword.
-func (p *Parser) joinClause(selectTree *ast.SelectQuery) {
-	// TODO
+func (p *Parser) joinClause() ast.SQLJoin {
+	join := ast.SQLJoin{}
+
+	switch p.word.Token {
+	case token.COMMA:
+		join.Type = ast.COMMA
+		p.next()
+	case token.JOIN:
+		join.Type = ast.JOIN
+		p.next()
+	case token.CROSS:
+		p.next()
+		join.Type = ast.CROSS
+		if p.word.Token != token.JOIN {
+			join.Type = ast.CROSSAPPLY
+		}
+		p.next()
+	case token.OUTER:
+		join.Type = ast.OUTERAPPLY
+		p.next()
+		p.next()
+	default:
+		joinType := p.word.Token
+		p.next()
+		outer := p.word.Token == token.OUTER
+		if outer {
+			p.next()
+		}
+		join.Type = sqlJoinType(joinType, outer)
+		if p.word.Token != token.JOIN {
+			join.Hints.Hint = p.word.Literal
+			p.next()
+		}
+		p.next()
+	}
+
+	join.RightTableName = *p.tableName()
+	if p.word.Token == token.ON {
+		p.next()
+		join.Condition = p.expression(func(ast.Word) bool {
+			return p.isJoinStart() || p.isClauseEnd()
+		})
+	}
+	return join
+}
+
+// Function sqlJoinType returns ast.SQLJoinType for INNER, LEFT, RIGHT or FULL
+// join type token with optional OUTER keyword.
+func sqlJoinType(joinType token.Token, outer bool) ast.SQLJoinType {
+	switch {
+	case joinType == token.LEFT && outer:
+		return ast.LEFTOUTER
+	case joinType == token.LEFT:
+		return ast.LEFT
+	case joinType == token.RIGHT && outer:
+		return ast.RIGHTOUTER
+	case joinType == token.RIGHT:
+		return ast.RIGHT
+	case joinType == token.FULL && outer:
+		return ast.FULLOUTER
+	case joinType == token.FULL:
+		return ast.FULL
+	}
+	return ast.INNER
+}
+
+// Method selectWhere parses WHERE clause in SELECT query.
+func (p *Parser) selectWhere(selectTree *ast.SelectQuery) {
+	if p.word.Token != token.WHERE {
+		return
+	}
+
+	p.next()
+	(*selectTree).Where = &ast.WhereClause{Condition: p.clauseExpression()}
+}
+
+// Method selectGroupBy parses GROUP BY clause in SELECT query. Options
+// WITH ROLLUP and WITH CUBE are kept in the last item.
+func (p *Parser) selectGroupBy(selectTree *ast.SelectQuery) {
+	if p.word.Token != token.GROUPBY {
+		return
+	}
+
+	p.next()
+	groupBy := ast.GroupByClause{}
+	for {
+		item := p.expression(func(w ast.Word) bool {
+			if w.Token == token.WITH {
+				next := p.peek().Token
+				return next != token.ROLLUP && next != token.CUBE
+			}
+			return w.Token == token.COMMA || p.isClauseEnd()
+		})
+		groupBy.Items = append(groupBy.Items, item)
+		if p.word.Token != token.COMMA {
+			break
+		}
+		p.next()
+	}
+	(*selectTree).GroupBy = &groupBy
+}
+
+// Method selectHaving parses HAVING clause in SELECT query.
+func (p *Parser) selectHaving(selectTree *ast.SelectQuery) {
+	if p.word.Token != token.HAVING {
+		return
+	}
+
+	p.next()
+	(*selectTree).Having = &ast.HavingClause{Condition: p.clauseExpression()}
+}
+
+// Method selectOrderBy parses ORDER BY clause in SELECT query together with
+// optional OFFSET ... FETCH ... expression.
+func (p *Parser) selectOrderBy(selectTree *ast.SelectQuery) {
+	if p.word.Token != token.ORDERBY {
+		return
+	}
+
+	p.next()
+	orderBy := ast.OrderByClause{}
+	for {
+		item := p.expression(func(w ast.Word) bool {
+			return w.Token == token.COMMA || IsWord(w, "OFFSET") ||
+				p.isClauseEnd()
+		})
+		orderBy.Items = append(orderBy.Items, item)
+		if p.word.Token != token.COMMA {
+			break
+		}
+		p.next()
+	}
+
+	if p.isWord("OFFSET") {
+		orderBy.Offset = p.clauseExpression()
+	}
+	(*selectTree).OrderBy = &orderBy
+}
+
+// Method selectFor parses FOR XML, FOR JSON or FOR BROWSE clause in SELECT
+// query. Each directive of the clause is kept as a separate expression.
+func (p *Parser) selectFor(selectTree *ast.SelectQuery) {
+	if !p.isForClause() {
+		return
+	}
+
+	p.next()
+	forClause := ast.ForClause{Mode: p.word.Literal}
+	p.next()
+	for !p.isClauseEnd() && p.word.Token != token.RPAREN &&
+		p.word.Token != token.EOF {
+		forClause.Options = append(forClause.Options,
+			p.expression(func(w ast.Word) bool {
+				return w.Token == token.COMMA || p.isClauseEnd()
+			}))
+		if p.word.Token != token.COMMA {
+			break
+		}
+		p.next()
+	}
+	(*selectTree).For = &forClause
+}
+
+// Method isForClause checks if current word starts FOR XML, FOR JSON or FOR
+// BROWSE clause of SELECT query.
+func (p *Parser) isForClause() bool {
+	if p.word.Token != token.FOR {
+		return false
+	}
+	next := p.peek()
+	return IsWord(next, "XML") || IsWord(next, "JSON") || IsWord(next, "BROWSE")
+}
+
+// Method selectOptions parses OPTION clause with query hints in SELECT query.
+func (p *Parser) selectOptions(selectTree *ast.SelectQuery) {
+	if p.word.Token != token.OPTION || p.peek().Token != token.LPAREN {
+		return
+	}
+
+	p.next()
+	(*selectTree).Options = &ast.SelectOptions{Hints: p.parenList()}
+}
+
+// Method clauseExpression parses expression till the end of current clause
+// of SELECT query.
+func (p *Parser) clauseExpression() ast.Expression {
+	return p.expression(func(ast.Word) bool {
+		return p.isClauseEnd()
+	})
 }
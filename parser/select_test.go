@@ -319,12 +319,286 @@ func TestParseSelectDistinct(t *testing.T) {
 	}
 
 	p2.selectDistinct(&st)
-	if *st.DistinctType != (ast.DistinctType{false, true}) {
+	if *st.DistinctType != (ast.DistinctType{All: false, Distinct: true}) {
 		t.Errorf("Wrong parsed case with DISTINCT")
 	}
 
 	p3.selectDistinct(&st)
-	if *st.DistinctType != (ast.DistinctType{true, false}) {
+	if *st.DistinctType != (ast.DistinctType{All: true, Distinct: false}) {
 		t.Errorf("Wrong parsed case with ALL")
 	}
 }
+
+// Test for parsing whole SELECT statement with all clauses and joins.
+func TestParseSelectStatement(t *testing.T) {
+	p := testParser(`SELECT a, Close FROM dbo.t AS t WITH (NOLOCK, INDEX(ix))
+	JOIN u ON u.id = t.id AND LEFT(u.x, 1) = 'a'
+	LEFT OUTER HASH JOIN (SELECT id FROM v) AS v (vid) ON v.vid = t.id
+	CROSS JOIN w OUTER APPLY dbo.f(t.id, 1) f, z
+	WHERE t.a > 0 GROUP BY a WITH ROLLUP HAVING COUNT(*) > 1
+	ORDER BY a DESC, b OFFSET 0 ROWS FETCH NEXT 5 ROWS ONLY OPTION (RECOMPILE)
+	SET @x = 1`)
+	stmt := p.statement().(*ast.SelectStatement)
+	query := stmt.Query
+
+	if len(query.Columns) != 2 || query.Columns[1][0].Literal != "Close" {
+		t.Errorf("Expected columns [a, Close], got: %v", query.Columns)
+	}
+	from := query.From
+	hints := from.TableOrViewName.Hints
+	if hints == nil || len(hints.Hints) != 2 {
+		t.Errorf("Expected 2 table hints, got: %v", hints)
+	}
+	expTypes := []ast.SQLJoinType{ast.JOIN, ast.LEFTOUTER, ast.CROSS,
+		ast.OUTERAPPLY, ast.COMMA}
+	if len(from.Joins) != len(expTypes) {
+		t.Fatalf("Expected %d joins, got: %d", len(expTypes), len(from.Joins))
+	}
+	for id, join := range from.Joins {
+		if join.Type != expTypes[id] {
+			t.Errorf("Expected join type %d, got: %d", expTypes[id], join.Type)
+		}
+	}
+	if len(from.Joins[0].Condition) != 18 {
+		t.Errorf("Expected 18 words in first join condition, got: %v",
+			from.Joins[0].Condition)
+	}
+	derived := from.Joins[1].RightTableName
+	if from.Joins[1].Hints.Hint != "HASH" || derived.Subquery == nil ||
+		*derived.Alias != "v" || len(derived.Columns) != 1 {
+		t.Errorf("Expected HASH join of derived table v (vid), got: %v", derived)
+	}
+	if len(from.Joins[3].RightTableName.Args) != 2 {
+		t.Errorf("Expected function with 2 arguments, got: %v",
+			from.Joins[3].RightTableName.Args)
+	}
+	if len(query.Where.Condition) != 5 || len(query.GroupBy.Items[0]) != 3 ||
+		len(query.Having.Condition) != 6 {
+		t.Errorf("Unexpected WHERE, GROUP BY or HAVING clause")
+	}
+	if len(query.OrderBy.Items) != 2 || len(query.OrderBy.Offset) != 8 {
+		t.Errorf("Expected 2 ORDER BY items and OFFSET, got: %v",
+			query.OrderBy)
+	}
+	if len(query.Options.Hints) != 1 {
+		t.Errorf("Expected OPTION (RECOMPILE), got: %v", query.Options.Hints)
+	}
+	if !p.isWord("SET") {
+		t.Errorf("Expected SET as the next statement, got: %v", p.word)
+	}
+}
+
+// Test for parsing WITH clause of OPENJSON function before its alias.
+func TestParseOpenJSON(t *testing.T) {
+	p := testParser(`SELECT j.id FROM t CROSS APPLY OPENJSON(t.js)
+	WITH (id int '$.id', v nvarchar(max) '$.v' AS JSON) j
+	SELECT 1`)
+	stmt := p.statement().(*ast.SelectStatement)
+
+	table := stmt.Query.From.Joins[0].RightTableName
+	if len(table.Schema) != 2 || len(table.Schema[1]) != 8 {
+		t.Errorf("Expected 2 column definitions, got: %v", table.Schema)
+	}
+	if table.Alias == nil || *table.Alias != "j" || table.Hints != nil {
+		t.Errorf("Expected alias j after WITH clause, got: %v", table.Alias)
+	}
+	if p.word.Token != token.SELECT {
+		t.Errorf("Expected SELECT as the next statement, got: %v", p.word)
+	}
+}
+
+// Test for recording positions of aliases of columns, table sources and
+// PIVOT operators. AS of other clauses doesn't precede an alias.
+func TestParseAliases(t *testing.T) {
+	p := testParser(`SELECT a x, CAST(b AS int) AS y, d AT TIME ZONE 'UTC'
+	FROM t AS u PIVOT (SUM(c) FOR k IN ([1])) AS pv JOIN v w ON 1 = 1
+	DECLARE @a AS int`)
+	script := p.Script()
+	exp := []string{"x", "y", "u", "pv", "w"}
+	if len(script.Aliases) != len(exp) {
+		t.Fatalf("Expected %d aliases, got: %v", len(exp), script.Aliases)
+	}
+	for id, pos := range script.Aliases {
+		if lit := p.source[pos.Offset].Literal; lit != exp[id] {
+			t.Errorf("Expected alias %s, got: %s", exp[id], lit)
+		}
+	}
+}
+
+// Test for parsing common table expressions and set operators.
+func TestParseSelectWith(t *testing.T) {
+	p := testParser(`WITH a AS (SELECT 1 AS x), b (y) AS (SELECT x FROM a)
+	SELECT y FROM b UNION ALL SELECT 2 EXCEPT SELECT 3 UNION (SELECT 4)`)
+	stmt := p.statement().(*ast.SelectStatement)
+
+	if len(stmt.With) != 2 || stmt.With[1].Name != "b" ||
+		len(stmt.With[1].Columns) != 1 || stmt.With[1].Query == nil {
+		t.Errorf("Expected 2 common table expressions, got: %v", stmt.With)
+	}
+	if len(stmt.SetOps) != 2 || !stmt.SetOps[0].All ||
+		stmt.SetOps[1].Operator != token.EXCEPT {
+		t.Errorf("Expected UNION ALL and EXCEPT, got: %v", stmt.SetOps)
+	}
+	if p.word.Token != token.UNION {
+		t.Errorf("Expected UNION before parenthesized query, got: %v", p.word)
+	}
+
+	p = testParser("WITH a AS (SELECT 1 AS x) UPDATE t SET x = 1")
+	if _, isRaw := p.statement().(*ast.RawStatement); !isRaw {
+		t.Errorf("Expected CTE with UPDATE parsed as raw statement")
+	}
+}
+
+// Test for structuring CASE expressions parsed as flat column expressions.
+func TestParseCase(t *testing.T) {
+	p := testParser(`SELECT CASE WHEN a = 1 THEN 'x' WHEN a = 2 THEN
+		CASE b WHEN 1 THEN 'y' END ELSE 'z' END + 'w', c FROM t`)
+	query := p.statement().(*ast.SelectStatement).Query
+	if len(query.Columns) != 2 {
+		t.Fatalf("Expected 2 columns, got: %d", len(query.Columns))
+	}
+
+	col := query.Columns[0]
+	searched := col[0].Case
+	if searched == nil || searched.End != col[len(col)-3].Pos {
+		t.Fatalf("Expected CASE ending at %v, got: %v", col[len(col)-3].Pos,
+			searched)
+	}
+	if searched.Input != nil || len(searched.Whens) != 2 ||
+		len(searched.Else) != 1 {
+		t.Errorf("Unexpected searched CASE: %v", searched)
+	}
+	if len(searched.Whens[0].Condition) != 3 ||
+		len(searched.Whens[0].Result) != 1 {
+		t.Errorf("Unexpected first WHEN: %v", searched.Whens[0])
+	}
+
+	result := searched.Whens[1].Result
+	simple := result[0].Case
+	if simple == nil || simple.End != result[6].Pos ||
+		len(simple.Input) != 1 || len(simple.Whens) != 1 || simple.Else != nil {
+		t.Errorf("Unexpected nested simple CASE: %v", simple)
+	}
+	for _, word := range query.Columns[1] {
+		if word.Case != nil {
+			t.Errorf("Expected no CASE in expression %v", query.Columns[1])
+		}
+	}
+}
+
+// Test for parsing PIVOT and UNPIVOT operators applied to table sources.
+func TestParsePivot(t *testing.T) {
+	p := testParser(`SELECT * FROM (SELECT a, b, c FROM t) AS s
+		PIVOT (SUM(c) FOR b IN ([x], [y])) AS pv
+		JOIN w UNPIVOT (v FOR col IN (d, e, f)) u ON u.a = pv.a`)
+	from := p.statement().(*ast.SelectStatement).Query.From
+	if from == nil || len(from.Joins) != 1 {
+		t.Fatalf("Expected FROM clause with 1 join, got: %v", from)
+	}
+
+	pivots := from.TableOrViewName.Pivots
+	if len(pivots) != 1 {
+		t.Fatalf("Expected 1 PIVOT operator, got: %d", len(pivots))
+	}
+	pivot := pivots[0]
+	if pivot.Unpivot || len(pivot.Value) != 4 || pivot.For != "b" ||
+		len(pivot.In) != 2 || pivot.In[1] != "[y]" || !pivot.ASKeyword ||
+		pivot.Alias != "pv" {
+		t.Errorf("Unexpected PIVOT operator: %v", pivot)
+	}
+
+	right := from.Joins[0].RightTableName
+	if right.Alias != nil || len(right.Pivots) != 1 {
+		t.Fatalf("Expected table w with UNPIVOT operator, got: %v", right)
+	}
+	unpivot := right.Pivots[0]
+	if !unpivot.Unpivot || len(unpivot.Value) != 1 || unpivot.For != "col" ||
+		len(unpivot.In) != 3 || unpivot.ASKeyword || unpivot.Alias != "u" {
+		t.Errorf("Unexpected UNPIVOT operator: %v", unpivot)
+	}
+	if len(from.Joins[0].Condition) != 7 {
+		t.Errorf("Expected join condition of 7 words, got: %v",
+			from.Joins[0].Condition)
+	}
+}
+
+// Test for parsing all variants of FOR SYSTEM_TIME clause of temporal tables.
+func TestParseSystemTime(t *testing.T) {
+	tests := []struct {
+		src   string
+		kind  ast.SystemTimeKind
+		start string
+		end   string
+		alias string
+	}{
+		{"SELECT * FROM t FOR SYSTEM_TIME AS OF @d AS x", ast.ASOF, "@d", "", "x"},
+		{"SELECT * FROM t FOR SYSTEM_TIME FROM @a TO @b x", ast.FROMTO, "@a", "@b", "x"},
+		{"SELECT * FROM t FOR SYSTEM_TIME BETWEEN '2020' AND '2021'",
+			ast.BETWEENAND, "'2020'", "'2021'", ""},
+		{"SELECT * FROM t FOR SYSTEM_TIME CONTAINED IN (@a, @b) x",
+			ast.CONTAINEDIN, "@a", "@b", "x"},
+		{"SELECT * FROM t FOR SYSTEM_TIME ALL x WHERE a = 1", ast.ALLTIME, "", "", "x"},
+	}
+
+	for _, test := range tests {
+		p := testParser(test.src)
+		table := p.statement().(*ast.SelectStatement).Query.From.TableOrViewName
+		clause := table.SystemTime
+		if clause == nil || clause.Kind != test.kind {
+			t.Errorf("Expected FOR SYSTEM_TIME of kind %d for %q, got: %v",
+				test.kind, test.src, clause)
+			continue
+		}
+		if test.start != "" && (len(clause.Start) != 1 ||
+			clause.Start[0].Literal != test.start) {
+			t.Errorf("Expected start %s, got: %v", test.start, clause.Start)
+		}
+		if test.end != "" && (len(clause.End) != 1 ||
+			clause.End[0].Literal != test.end) {
+			t.Errorf("Expected end %s, got: %v", test.end, clause.End)
+		}
+		if (test.alias == "") != (table.Alias == nil) ||
+			table.Alias != nil && *table.Alias != test.alias {
+			t.Errorf("Expected alias %q for %q, got: %v", test.alias, test.src,
+				table.Alias)
+		}
+	}
+}
+
+// Test for parsing FOR XML, FOR JSON and FOR BROWSE clauses of SELECT query.
+func TestParseForClause(t *testing.T) {
+	p := testParser(`SELECT a FROM t WHERE b = 1 ORDER BY a
+		FOR XML PATH('row'), TYPE, ROOT('rows') OPTION (RECOMPILE)`)
+	query := p.statement().(*ast.SelectStatement).Query
+	if len(query.Where.Condition) != 3 || len(query.OrderBy.Items) != 1 {
+		t.Errorf("Unexpected WHERE or ORDER BY clause: %v, %v", query.Where,
+			query.OrderBy)
+	}
+	if query.For == nil || query.For.Mode != "XML" ||
+		len(query.For.Options) != 3 || len(query.For.Options[0]) != 4 {
+		t.Fatalf("Unexpected FOR XML clause: %v", query.For)
+	}
+	if query.Options == nil {
+		t.Errorf("Expected OPTION clause after FOR XML clause")
+	}
+
+	p = testParser("SELECT a FROM t FOR JSON AUTO; SELECT b FROM u FOR BROWSE")
+	query = p.statement().(*ast.SelectStatement).Query
+	if query.For == nil || query.For.Mode != "JSON" ||
+		len(query.For.Options) != 1 {
+		t.Errorf("Unexpected FOR JSON clause: %v", query.For)
+	}
+	query = p.statement().(*ast.SelectStatement).Query
+	if query.For == nil || query.For.Mode != "BROWSE" ||
+		len(query.For.Options) != 0 {
+		t.Errorf("Unexpected FOR BROWSE clause: %v", query.For)
+	}
+
+	p = testParser("SELECT x = (SELECT a FROM t FOR XML PATH('')) FROM u")
+	query = p.statement().(*ast.SelectStatement).Query
+	if len(query.Columns) != 1 || len(query.Columns[0]) != 14 ||
+		query.From == nil {
+		t.Errorf("Expected subquery with FOR XML in column, got: %v",
+			query.Columns)
+	}
+}
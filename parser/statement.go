@@ -0,0 +1,487 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+	"strings"
+)
+
+// Method Script parses whole T-SQL script into batches of statements.
+// Comments of the script are associated with parsed nodes in comment map.
+func (p *Parser) Script() *ast.Script {
+	script := ast.Script{}
+
+	for p.word.Token != token.EOF {
+		script.Batches = append(script.Batches, p.batch())
+	}
+	script.Comments = p.commentMap(&script)
+	script.Spelling = p.spelling()
+	script.Aliases = p.aliases
+	return &script
+}
+
+// Method batch parses statements till GO command or the end of the script.
+func (p *Parser) batch() *ast.Batch {
+	batch := ast.Batch{}
+	start := p.word.Pos.Offset
+	batch.Statements = p.statementList(func() bool { return false })
+
+	if p.word.Token == token.GO {
+		batch.Go = true
+		p.next()
+		if p.word.Token == token.INT {
+			batch.Count = p.word.Literal
+			p.next()
+		}
+	}
+	p.batches = append(p.batches,
+		nodeRange{node: &batch, start: start, end: p.prev.Pos.Offset})
+	return &batch
+}
+
+// Method statementList parses statements until the end of the batch or until
+// stop function returns true.
+func (p *Parser) statementList(stop func() bool) []ast.Statement {
+	stmts := make([]ast.Statement, 0, 10)
+
+	for p.word.Token != token.EOF && p.word.Token != token.GO && !stop() {
+		stmts = append(stmts, p.statement())
+	}
+	return stmts
+}
+
+// Method statement parses single T-SQL statement starting at current word.
+// Statements which aren't supported by the parser are parsed as
+// ast.RawStatement.
+func (p *Parser) statement() ast.Statement {
+	var stmt ast.Statement
+	first := p.word
+
+	switch p.word.Token {
+	case token.SEMICOLON:
+		stmt = &ast.EmptyStatement{}
+	case token.BEGIN:
+		stmt = p.beginStatement()
+	case token.CREATE, token.ALTER:
+		stmt = p.createStatement()
+	case token.DROP:
+		stmt = p.dropStatement()
+	case token.RETURN:
+		stmt = p.returnStatement()
+	case token.IF:
+		stmt = p.ifStatement()
+	case token.WHILE:
+		stmt = p.whileStatement()
+	case token.BREAK:
+		stmt = &ast.BreakStatement{}
+		p.next()
+	case token.CONTINUE:
+		stmt = &ast.ContinueStatement{}
+		p.next()
+	case token.GOTO:
+		stmt = p.gotoStatement()
+	case token.OPEN, token.CLOSE, token.DEALLOCATE:
+		stmt = p.cursorStatement()
+	case token.FETCH:
+		stmt = p.fetchStatement()
+	case token.SELECT:
+		stmt = p.selectStatement()
+	case token.UPDATE:
+		stmt = p.updateStatement()
+	case token.WITH:
+		if p.isSelectWith() {
+			stmt = p.selectStatement()
+		} else {
+			stmt = p.rawStatement()
+		}
+	default:
+		switch {
+		case p.isLabel():
+			stmt = p.labelStatement()
+		case p.isTransaction():
+			stmt = p.transactionStatement()
+		case p.isWord("DECLARE"):
+			stmt = p.declareStatement()
+		case p.isWord("SET"):
+			stmt = p.setStatement()
+		case p.isWord("EXEC") || p.isWord("EXECUTE"):
+			stmt = p.execStatement()
+		case p.isWord("THROW"):
+			stmt = p.throwStatement()
+		case p.isWord("RAISERROR") && p.peek().Token == token.LPAREN:
+			stmt = p.raiserrorStatement()
+		default:
+			stmt = p.rawStatement()
+		}
+	}
+
+	if p.word.Token == token.SEMICOLON {
+		stmt.Terminate()
+		p.next()
+	}
+	stmt.SetRange(first.Pos, p.prev.Pos)
+	p.statements = append(p.statements, stmt)
+	return stmt
+}
+
+// Method beginStatement parses statements starting with BEGIN keyword: BEGIN
+// ... END blocks, TRY ... CATCH and BEGIN TRANSACTION. Other statements (like
+// BEGIN DIALOG) are parsed as ast.RawStatement.
+func (p *Parser) beginStatement() ast.Statement {
+	next := p.peek()
+	switch {
+	case IsWord(next, "TRY"):
+		return p.tryCatchStatement()
+	case p.isTransaction():
+		return p.transactionStatement()
+	case IsWord(next, "DIALOG") || IsWord(next, "CONVERSATION"):
+		return p.rawStatement()
+	}
+	return p.blockStatement()
+}
+
+// Method blockStatement parses BEGIN ... END block. This method assumes that
+// current word is BEGIN.
+func (p *Parser) blockStatement() *ast.BlockStatement {
+	block := ast.BlockStatement{}
+	p.next()
+	block.Statements = p.statementList(func() bool {
+		return p.word.Token == token.END
+	})
+
+	if p.word.Token == token.END {
+		p.next()
+	}
+	return &block
+}
+
+// Method createStatement parses CREATE, ALTER and CREATE OR ALTER statements.
+// Objects which aren't supported by the parser are parsed as
+// ast.RawStatement.
+func (p *Parser) createStatement() ast.Statement {
+	if p.word.Token == token.CREATE && p.isIndex() {
+		return p.createIndex()
+	}
+
+	object := p.peek()
+	if object.Token == token.OR {
+		object = p.peekN(3)
+	}
+
+	switch object.Token {
+	case token.PROC, token.PROCEDURE:
+		return p.procedure(p.createMode())
+	case token.FUNCTION:
+		return p.function(p.createMode())
+	case token.VIEW:
+		return p.view(p.createMode())
+	case token.TRIGGER:
+		return p.trigger(p.createMode())
+	case token.TABLE:
+		if p.word.Token == token.CREATE {
+			return p.createTable()
+		}
+		return p.alterTable()
+	}
+	return p.rawStatement()
+}
+
+// Method createMode parses CREATE, ALTER or CREATE OR ALTER keywords at the
+// beginning of DDL statements.
+func (p *Parser) createMode() ast.CreateMode {
+	if p.word.Token == token.ALTER {
+		p.next()
+		return ast.ALTER
+	}
+
+	p.next()
+	if p.word.Token == token.OR {
+		p.next()
+		p.next()
+		return ast.CREATEORALTER
+	}
+	return ast.CREATE
+}
+
+// Method rawStatement parses statement which isn't supported by the parser as
+// a flat list of words. Statement ends on semicolon, GO, END of the enclosing
+// block or on the beginning of the next statement.
+func (p *Parser) rawStatement() *ast.RawStatement {
+	raw := ast.RawStatement{Words: ast.Expression{p.word}}
+	first := p.word
+	depth := 0
+	if first.Token == token.LPAREN || first.Token == token.CASE {
+		depth++
+	}
+	p.next()
+
+	for p.word.Token != token.EOF && p.word.Token != token.GO {
+		if depth == 0 && p.isRawStatementEnd(raw.Words) {
+			break
+		}
+		switch p.word.Token {
+		case token.LPAREN, token.CASE:
+			depth++
+		case token.RPAREN, token.END:
+			depth--
+		}
+		raw.Words = append(raw.Words, p.word)
+		p.next()
+	}
+	raw.Complete = isComplete(raw.Words)
+	return &raw
+}
+
+// Function isComplete checks if words of raw statement form INSERT, DELETE or
+// MERGE statement which ends with an operand, or with DELETE action of MERGE,
+// so the statement can't continue in the following words.
+func isComplete(words ast.Expression) bool {
+	first, last := words[0], words[len(words)-1]
+	switch {
+	case first.Token == token.INSERT || first.Token == token.DELETE:
+		return IsOperand(last)
+	case IsWord(first, "MERGE") && len(words) > 1:
+		return IsOperand(last) || last.Token == token.DELETE &&
+			words[len(words)-2].Token == token.THEN
+	}
+	return false
+}
+
+// Method isRawStatementEnd checks if current word ends raw statement which
+// consists of given words. Raw statement ends on semicolon, END of the
+// enclosing block or on the start of the next statement (see statementStarts)
+// which doesn't continue it. Query in parentheses starts a new statement only
+// after an operand.
+func (p *Parser) isRawStatementEnd(words ast.Expression) bool {
+	prev := words[len(words)-1]
+	switch {
+	case p.word.Token == token.SEMICOLON || p.word.Token == token.END:
+		return true
+	case p.isQueryStart():
+		return !isQueryPart(words) && IsOperand(prev) && !IsWord(prev, "USING")
+	}
+	start, ok := p.statementStart()
+	return ok && (start.continues == nil || !start.continues(p, words))
+}
+
+// Function hasWord checks if given words contain a word outside parentheses
+// for which match returns true.
+func hasWord(words ast.Expression, match func(ast.Word) bool) bool {
+	depth := 0
+	for _, word := range words {
+		switch word.Token {
+		case token.LPAREN:
+			depth++
+		case token.RPAREN:
+			depth--
+		default:
+			if depth == 0 && match(word) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Function isPermission checks if word after prev is a permission in GRANT,
+// DENY or REVOKE statement which started with first word, like SELECT or
+// EXECUTE in "GRANT SELECT, EXECUTE ON ...".
+func isPermission(first, prev ast.Word) bool {
+	permissionStatement := func(w ast.Word) bool {
+		return IsWord(w, "GRANT") || IsWord(w, "DENY") || IsWord(w, "REVOKE")
+	}
+	return permissionStatement(first) &&
+		(permissionStatement(prev) || prev.Token == token.COMMA)
+}
+
+// Method isStatementStart checks if current word starts a new statement. It
+// doesn't take context into account, for example SELECT after INSERT INTO
+// also starts a new statement according to this method.
+func (p *Parser) isStatementStart() bool {
+	_, ok := p.statementStart()
+	return ok
+}
+
+// Method statementStart returns description of the statement start at the
+// current word. False is returned when the current word isn't a label nor
+// a keyword starting statements in its place.
+func (p *Parser) statementStart() (statementStart, bool) {
+	if p.isLabel() {
+		return statementStart{}, true
+	}
+	if p.word.Token != token.IDENT && !p.word.Token.IsKeyword() {
+		return statementStart{}, false
+	}
+	start, ok := statementStarts[strings.ToUpper(p.word.Literal)]
+	if !ok || start.starts != nil && !start.starts(p) {
+		return statementStart{}, false
+	}
+	return start, true
+}
+
+// Type statementStart describes a keyword which starts statements. Function
+// starts checks if the current word really starts a statement, like WITH
+// which has to start common table expressions. Function continues checks if
+// the current word continues raw statement consisting of given words instead
+// of starting a new one, like SELECT after INSERT. Nil functions mean that
+// the keyword always starts a statement.
+type statementStart struct {
+	starts    func(p *Parser) bool
+	continues func(p *Parser, words ast.Expression) bool
+}
+
+// Keywords which start statements by their upper case literals.
+var statementStarts = map[string]statementStart{
+	"ALTER":      {notBefore(token.COLUMN), continuesPermission},
+	"BEGIN":      {},
+	"BREAK":      {},
+	"CLOSE":      {},
+	"COMMIT":     {},
+	"CONTINUE":   {},
+	"CREATE":     {nil, continuesPermission},
+	"DBCC":       {},
+	"DEALLOCATE": {},
+	"DECLARE":    {},
+	"DELETE":     {notBefore(token.LPAREN), continuesModification},
+	"DENY":       {},
+	"DROP":       {},
+	"ELSE":       {},
+	"EXEC":       {nil, continuesExec},
+	"EXECUTE":    {nil, continuesExec},
+	"FETCH":      {startsFetch, nil},
+	"GOTO":       {},
+	"GRANT":      {},
+	"IF":         {nil, continuesIf},
+	"INSERT":     {notBefore(token.LPAREN), continuesModification},
+	"MERGE":      {nil, continuesMerge},
+	"OPEN":       {},
+	"PRINT":      {},
+	"RAISERROR":  {},
+	"RETURN":     {},
+	"REVERT":     {},
+	"REVOKE":     {},
+	"ROLLBACK":   {},
+	"SAVE":       {},
+	"SELECT":     {nil, continuesSelect},
+	"SET":        {notBefore(token.LPAREN), continuesSet},
+	"THROW":      {},
+	"TRUNCATE":   {},
+	"UPDATE":     {notBefore(token.LPAREN), continuesModification},
+	"USE":        {},
+	"WAITFOR":    {},
+	"WHILE":      {},
+	"WITH":       {(*Parser).isCTE, nil},
+}
+
+// Function notBefore returns starts function of statementStart which is true
+// when the keyword isn't followed by given token, like UPDATE which isn't
+// the UPDATE() function of triggers.
+func notBefore(tok token.Token) func(p *Parser) bool {
+	return func(p *Parser) bool {
+		return p.peek().Token != tok
+	}
+}
+
+// Function startsFetch checks if FETCH starts a statement, so it isn't
+// a part of OFFSET ... ROWS FETCH clause.
+func startsFetch(p *Parser) bool {
+	return p.prev.Token != token.ROWS && !IsWord(p.prev, "ROW")
+}
+
+// Function continuesMerge checks if MERGE continues raw statement: it's
+// a statement of common table expressions or MERGE join hint.
+func continuesMerge(p *Parser, words ast.Expression) bool {
+	return words[0].Token == token.WITH ||
+		words[len(words)-1].Token.IsJoinType()
+}
+
+// Function continuesPermission checks if the current word is a permission in
+// GRANT, DENY or REVOKE statement (see isPermission).
+func continuesPermission(p *Parser, words ast.Expression) bool {
+	return isPermission(words[0], words[len(words)-1])
+}
+
+// Function continuesSelect checks if SELECT continues raw statement: it's
+// a query of INSERT, of common table expressions or after a set operator,
+// FOR SELECT of a cursor or SELECT permission.
+func continuesSelect(p *Parser, words ast.Expression) bool {
+	return isQueryPart(words) || words[len(words)-1].Token == token.FOR ||
+		continuesPermission(p, words)
+}
+
+// Function isQueryPart checks if query following given words of raw
+// statement belongs to it: the statement is INSERT without its source yet,
+// common table expressions or the query follows a set operator.
+func isQueryPart(words ast.Expression) bool {
+	first, prev := words[0], words[len(words)-1]
+	switch prev.Token {
+	case token.UNION, token.EXCEPT, token.INTERSECT, token.ALL:
+		return true
+	}
+	return first.Token == token.WITH || first.Token == token.INSERT &&
+		!hasWord(words, func(w ast.Word) bool {
+			return w.Token == token.SELECT || w.Token == token.VALUES
+		})
+}
+
+// Function continuesExec checks if EXEC continues raw statement: it's
+// a source of INSERT, EXECUTE AS option of a module or EXECUTE permission.
+func continuesExec(p *Parser, words ast.Expression) bool {
+	return words[0].Token == token.INSERT ||
+		words[len(words)-1].Token == token.WITH ||
+		continuesPermission(p, words)
+}
+
+// Function continuesModification checks if INSERT, UPDATE or DELETE
+// continues raw statement: it's a statement of common table expressions,
+// an action of MERGE, a permission or an event of a trigger or a cursor.
+func continuesModification(p *Parser, words ast.Expression) bool {
+	first, prev := words[0], words[len(words)-1]
+	ddl := first.Token == token.CREATE || first.Token == token.ALTER
+	switch {
+	case first.Token == token.WITH, prev.Token == token.ON && ddl:
+		return true
+	case prev.Token == token.FOR, prev.Token == token.COMMA,
+		prev.Token == token.THEN:
+		return true
+	}
+	return IsWord(prev, "AFTER") || IsWord(prev, "OF") ||
+		continuesPermission(p, words)
+}
+
+// Function continuesSet checks if SET continues raw statement: it's SET
+// clause of UPDATE (also in common table expressions), ALTER DATABASE or
+// MERGE, or a part of ON UPDATE SET NULL or ON DELETE SET NULL actions.
+func continuesSet(p *Parser, words ast.Expression) bool {
+	first, prev := words[0], words[len(words)-1]
+	hasSet := hasWord(words, func(w ast.Word) bool {
+		return IsWord(w, "SET")
+	})
+	hasUpdate := hasWord(words, func(w ast.Word) bool {
+		return w.Token == token.UPDATE
+	})
+	switch {
+	case IsWord(first, "MERGE"):
+		return true
+	case prev.Token == token.UPDATE || prev.Token == token.DELETE:
+		return true
+	case first.Token == token.UPDATE:
+		return !hasSet && !(len(words) > 1 && IsWord(words[1], "STATISTICS"))
+	case first.Token == token.WITH:
+		return !hasSet && hasUpdate
+	}
+	return first.Token == token.ALTER && len(words) > 1 &&
+		IsWord(words[1], "DATABASE") && !hasSet
+}
+
+// Function continuesIf checks if IF continues raw statement: it's IF EXISTS
+// of DROP statement or DROP clause of ALTER TABLE.
+func continuesIf(p *Parser, words ast.Expression) bool {
+	drop := words[0].Token == token.DROP || words[0].Token == token.ALTER &&
+		len(words) > 1 && words[1].Token == token.TABLE &&
+		hasWord(words, func(w ast.Word) bool {
+			return w.Token == token.DROP
+		})
+	return drop && p.peek().Token == token.EXISTS &&
+		p.peekN(2).Token != token.LPAREN
+}
@@ -0,0 +1,250 @@
+package parser
+
+import (
+	"fmt"
+	"mssfmt/ast"
+	"mssfmt/token"
+	"testing"
+)
+
+// Test for splitting script into batches.
+func TestParseScriptBatches(t *testing.T) {
+	p := testParser("SELECT 1\nGO\nSELECT 2; SELECT 3\nGO 5\nSELECT 4")
+	script := p.Script()
+
+	if len(script.Batches) != 3 {
+		t.Fatalf("Expected 3 batches, got: %d", len(script.Batches))
+	}
+	b1, b2, b3 := script.Batches[0], script.Batches[1], script.Batches[2]
+
+	if !b1.Go || b1.Count != "" || len(b1.Statements) != 1 {
+		t.Errorf("Expected single statement batch ended by GO, got: %v", b1)
+	}
+	if !b2.Go || b2.Count != "5" || len(b2.Statements) != 2 {
+		t.Errorf("Expected two statements batch ended by GO 5, got: %v", b2)
+	}
+	if b3.Go || len(b3.Statements) != 1 {
+		t.Errorf("Expected single statement batch without GO, got: %v", b3)
+	}
+	if !b2.Statements[0].Terminated() || b2.Statements[1].Terminated() {
+		t.Errorf("Expected only first statement in second batch terminated")
+	}
+}
+
+// Test for splitting unsupported statements into raw statements.
+func TestParseRawStatements(t *testing.T) {
+	p := testParser(`WAITFOR DELAY '00:00:01'
+		INSERT INTO t (a) SELECT a FROM u UNION ALL SELECT 1
+		DELETE FROM t WHERE a = CASE WHEN b = 1 THEN 2 END OR c IN (SELECT c FROM x)
+		;WITH cte AS (SELECT 1 AS a) DELETE FROM cte`)
+	stmts := p.statementList(func() bool { return false })
+
+	if len(stmts) != 4 {
+		t.Fatalf("Expected 4 statements, got: %d", len(stmts))
+	}
+	expFirst := []token.Token{token.IDENT, token.INSERT, token.DELETE,
+		token.WITH}
+	expLen := []int{3, 14, 23, 12}
+
+	for id, stmt := range stmts {
+		raw, isRaw := stmt.(*ast.RawStatement)
+		if !isRaw {
+			t.Errorf("Expected raw statement, got: %T", stmt)
+			continue
+		}
+		if raw.Words[0].Token != expFirst[id] {
+			t.Errorf("Expected statement starting with [%s], got: [%s]",
+				expFirst[id], raw.Words[0].Token)
+		}
+		if len(raw.Words) != expLen[id] {
+			t.Errorf("Expected %d words in statement %d, got: %d", expLen[id],
+				id, len(raw.Words))
+		}
+	}
+}
+
+// Test for ends of raw statements which are followed by queries: INSERT
+// with VALUES or SELECT source, query in parentheses and common table
+// expressions of UPDATE.
+func TestParseRawStatementEnd(t *testing.T) {
+	p := testParser(`INSERT INTO t (a, b) VALUES (1, 2)
+		SELECT * FROM t
+		INSERT INTO t SELECT a FROM u
+		SELECT 1
+		(SELECT 2)
+		WITH x AS (SELECT 1 AS a) UPDATE x SET a = 1
+		SET NOCOUNT ON`)
+	stmts := p.statementList(func() bool { return false })
+
+	expTypes := []string{"*ast.RawStatement", "*ast.SelectStatement",
+		"*ast.RawStatement", "*ast.SelectStatement", "*ast.RawStatement",
+		"*ast.RawStatement", "*ast.SetOptionStatement"}
+	if len(stmts) != len(expTypes) {
+		t.Fatalf("Expected %d statements, got: %d", len(expTypes), len(stmts))
+	}
+	for id, stmt := range stmts {
+		if typ := fmt.Sprintf("%T", stmt); typ != expTypes[id] {
+			t.Errorf("Expected %s as statement %d, got: %s", expTypes[id], id,
+				typ)
+		}
+	}
+	if raw := stmts[5].(*ast.RawStatement); len(raw.Words) != 15 {
+		t.Errorf("Expected 15 words of UPDATE with CTE, got: %d",
+			len(raw.Words))
+	}
+}
+
+// Test for complete raw statements. INSERT, DELETE and MERGE are complete
+// when they end with an operand or with DELETE action of MERGE.
+func TestParseRawComplete(t *testing.T) {
+	p := testParser(`INSERT INTO t (a) VALUES (1)
+		DELETE FROM t WHERE a = 1
+		MERGE t USING s ON t.id = s.id WHEN MATCHED THEN DELETE
+		INSERT INTO t DEFAULT VALUES
+		GRANT SELECT ON t TO u`)
+	stmts := p.statementList(func() bool { return false })
+
+	expComplete := []bool{true, true, true, false, false}
+	if len(stmts) != len(expComplete) {
+		t.Fatalf("Expected %d statements, got: %d", len(expComplete),
+			len(stmts))
+	}
+	for id, stmt := range stmts {
+		raw, ok := stmt.(*ast.RawStatement)
+		if !ok {
+			t.Errorf("Expected raw statement %d, got: %T", id, stmt)
+		} else if raw.Complete != expComplete[id] {
+			t.Errorf("Expected complete %v of statement %d, got: %v",
+				expComplete[id], id, raw.Complete)
+		}
+	}
+}
+
+// Test for the table of statement starts. Each keyword ends raw statement
+// unless it continues it in the given context.
+func TestParseStatementStarts(t *testing.T) {
+	tests := []struct {
+		src   string
+		count int
+	}{
+		{"EXECUTE AS LOGIN = 'y'\nREVERT", 2},
+		{"GRANT SELECT, INSERT ON t TO u\nPRINT 1", 2},
+		{"GRANT CREATE TABLE TO u\nDBCC CHECKDB", 2},
+		{"GRANT EXECUTE ON p TO u\nWAITFOR DELAY '00:01'", 2},
+		{"INSERT INTO t EXEC p\nUSE db", 2},
+		{"INSERT INTO t VALUES (1)\nINSERT INTO u VALUES (2)", 2},
+		{"DECLARE c CURSOR FOR SELECT a FROM t\nOPEN c", 2},
+		{"DECLARE c CURSOR FOR SELECT a FROM t FOR UPDATE OF a\nOPEN c", 2},
+		{"ALTER TABLE t ALTER COLUMN a int\nTRUNCATE TABLE t", 2},
+		{"ALTER TABLE t DROP COLUMN IF EXISTS a\nIF 1 = 1 PRINT 1", 2},
+		{"ALTER DATABASE db SET RECOVERY SIMPLE\nSET NOCOUNT ON", 2},
+		{"ALTER INDEX ix ON t REBUILD\nSET NOCOUNT ON", 2},
+		{"MERGE t USING s ON t.id = s.id WHEN MATCHED THEN DELETE\n" +
+			"MERGE u USING s ON u.id = s.id WHEN MATCHED THEN DELETE", 2},
+		{"DELETE t FROM t INNER MERGE JOIN u ON t.id = u.id\nPRINT 1", 2},
+		{"SELECT a FROM t ORDER BY a OFFSET 1 ROWS FETCH NEXT 1 ROWS ONLY\n" +
+			"FETCH NEXT FROM c", 2},
+		{"WITH x AS (SELECT 1 AS a) DELETE FROM x\nSAVE TRANSACTION s", 2},
+		{"BACKUP DATABASE db TO DISK = 'x'\nREVOKE SELECT ON t FROM u", 2},
+	}
+	for _, test := range tests {
+		p := testParser(test.src)
+		stmts := p.statementList(func() bool { return false })
+		if len(stmts) != test.count {
+			t.Errorf("Expected %d statements in %q, got: %d", test.count,
+				test.src, len(stmts))
+		}
+	}
+}
+
+// Test for ends of statements after keywords which are operands, like column
+// Count or DEFAULT value of an argument.
+func TestParseOperandKeywordEnd(t *testing.T) {
+	p := testParser(`SELECT a FROM t ORDER BY Count
+	DROP TABLE t
+	EXEC @rc = p @a = DEFAULT
+	EXEC ('x')`)
+	stmts := p.statementList(func() bool { return false })
+
+	expTypes := []string{"*ast.SelectStatement", "*ast.DropStatement",
+		"*ast.ExecStatement", "*ast.ExecStatement"}
+	if len(stmts) != len(expTypes) {
+		t.Fatalf("Expected %d statements, got: %d", len(expTypes), len(stmts))
+	}
+	for id, stmt := range stmts {
+		if typ := fmt.Sprintf("%T", stmt); typ != expTypes[id] {
+			t.Errorf("Expected %s as statement %d, got: %s", expTypes[id], id,
+				typ)
+		}
+	}
+	if exec := stmts[2].(*ast.ExecStatement); len(exec.Args) != 1 ||
+		len(exec.Args[0].Value) != 1 {
+		t.Errorf("Expected single argument @a = DEFAULT, got: %v", exec.Args)
+	}
+}
+
+// Test for ends of raw ALTER and DROP statements. Following IF and SET
+// statements aren't part of them, except SET options of ALTER DATABASE and
+// IF EXISTS of DROP.
+func TestParseRawAlterEnd(t *testing.T) {
+	p := testParser(`ALTER INDEX ix ON t REBUILD
+		IF OBJECT_ID('t') IS NOT NULL DROP TABLE t
+		ALTER INDEX ix ON t REBUILD
+		SET @a = 1
+		ALTER INDEX ix ON t SET (ALLOW_PAGE_LOCKS = ON)
+		ALTER DATABASE db SET RECOVERY SIMPLE
+		SET NOCOUNT ON
+		DROP SCHEMA IF EXISTS s
+		DROP SCHEMA s
+		IF EXISTS (SELECT 1) PRINT 1`)
+	stmts := p.statementList(func() bool { return false })
+
+	expTypes := []string{"*ast.RawStatement", "*ast.IfStatement",
+		"*ast.RawStatement", "*ast.SetStatement", "*ast.RawStatement",
+		"*ast.RawStatement", "*ast.SetOptionStatement", "*ast.RawStatement",
+		"*ast.RawStatement", "*ast.IfStatement"}
+	expLen := []int{6, 0, 6, 0, 11, 6, 0, 5, 3, 0}
+	if len(stmts) != len(expTypes) {
+		t.Fatalf("Expected %d statements, got: %d", len(expTypes), len(stmts))
+	}
+	for id, stmt := range stmts {
+		if typ := fmt.Sprintf("%T", stmt); typ != expTypes[id] {
+			t.Errorf("Expected %s as statement %d, got: %s", expTypes[id], id,
+				typ)
+		}
+		raw, isRaw := stmt.(*ast.RawStatement)
+		if isRaw && len(raw.Words) != expLen[id] {
+			t.Errorf("Expected %d words in statement %d, got: %d", expLen[id],
+				id, len(raw.Words))
+		}
+	}
+}
+
+// Test for parsing nested BEGIN ... END blocks.
+func TestParseBlockStatement(t *testing.T) {
+	p := testParser(`BEGIN
+		SELECT CASE WHEN a = 1 THEN 2 END
+		BEGIN SELECT 2 END;
+		BEGIN TRAN
+	END`)
+	stmt := p.statement()
+
+	block, isBlock := stmt.(*ast.BlockStatement)
+	if !isBlock {
+		t.Fatalf("Expected block statement, got: %T", stmt)
+	}
+	if len(block.Statements) != 3 {
+		t.Fatalf("Expected 3 statements in block, got: %d", len(block.Statements))
+	}
+	inner, isBlock := block.Statements[1].(*ast.BlockStatement)
+	if !isBlock || !inner.Terminated() || len(inner.Statements) != 1 {
+		t.Errorf("Expected terminated inner block with single statement")
+	}
+	if _, isTran := block.Statements[2].(*ast.TransactionStatement); !isTran {
+		t.Errorf("Expected BEGIN TRAN statement, got: %T",
+			block.Statements[2])
+	}
+	if p.word.Token != token.EOF {
+		t.Errorf("Expected EOF after block, got: [%s]", p.word.Token)
+	}
+}
@@ -0,0 +1,367 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+)
+
+// Method createTable parses CREATE TABLE statement. This method assumes that
+// current word is CREATE.
+func (p *Parser) createTable() *ast.CreateTableStatement {
+	table := ast.CreateTableStatement{}
+	p.next()
+	p.next()
+	table.Name = p.objectName()
+
+	if p.word.Token == token.LPAREN {
+		table.Definition = p.tableDefinition()
+	}
+	if p.word.Token == token.ON {
+		p.next()
+		table.FileGroup = ast.Expression{p.word}
+		p.next()
+		if p.word.Token == token.LPAREN {
+			table.FileGroup = append(table.FileGroup, p.parenthesized()...)
+		}
+	}
+	table.Options = p.expression(func(ast.Word) bool {
+		return p.isColumnDefinitionEnd()
+	})
+	return &table
+}
+
+// Method alterTable parses ALTER TABLE statement. Actions other than ADD,
+// ALTER COLUMN, DROP and {CHECK | NOCHECK} CONSTRAINT are kept as flat
+// expression. This method assumes that current word is ALTER.
+func (p *Parser) alterTable() *ast.AlterTableStatement {
+	alter := ast.AlterTableStatement{}
+	p.next()
+	p.next()
+	alter.Name = p.objectName()
+
+	if p.word.Token == token.WITH &&
+		(p.peek().Token == token.CHECK || IsWord(p.peek(), "NOCHECK")) {
+		p.next()
+		alter.With = p.word.Literal
+		p.next()
+	}
+
+	switch {
+	case p.isWord("ADD"):
+		alter.Action = ast.ADDACTION
+		p.next()
+		for {
+			alter.Elements = append(alter.Elements, p.tableElement())
+			if p.word.Token != token.COMMA {
+				break
+			}
+			p.next()
+		}
+	case p.word.Token == token.ALTER && p.peek().Token == token.COLUMN:
+		alter.Action = ast.ALTERCOLUMNACTION
+		p.next()
+		p.next()
+		alter.Column = p.columnDefinition()
+	case p.word.Token == token.DROP:
+		alter.Action = ast.DROPACTION
+		p.next()
+		alter.Drops = p.dropItems()
+	case (p.word.Token == token.CHECK || p.isWord("NOCHECK")) &&
+		p.peek().Token == token.CONSTRAINT:
+		alter.Action = ast.CHECKACTION
+		if p.word.Token != token.CHECK {
+			alter.Action = ast.NOCHECKACTION
+		}
+		p.next()
+		p.next()
+		alter.Constraints = p.constraintNames()
+	default:
+		alter.Action = ast.OTHERACTION
+		alter.Words = p.expression(func(ast.Word) bool {
+			return p.isColumnDefinitionEnd() && p.word.Token != token.COMMA
+		})
+	}
+	return &alter
+}
+
+// Method dropItems parses comma-separated list of constraints and columns in
+// ALTER TABLE ... DROP statement. CONSTRAINT or COLUMN keyword applies to all
+// following items, but it's kept only in the item where it was written.
+func (p *Parser) dropItems() []*ast.DropItem {
+	items := make([]*ast.DropItem, 0, 2)
+
+	for {
+		item := ast.DropItem{}
+		if p.word.Token == token.CONSTRAINT || p.word.Token == token.COLUMN {
+			item.Kind = p.word.Literal
+			p.next()
+		}
+		if p.word.Token == token.IF && p.peek().Token == token.EXISTS {
+			item.IfExists = true
+			p.next()
+			p.next()
+		}
+		item.Name = p.word.Literal
+		p.next()
+		if p.word.Token == token.WITH && p.peek().Token == token.LPAREN {
+			item.Options = ast.Expression{p.word}
+			p.next()
+			item.Options = append(item.Options, p.parenthesized()...)
+		}
+		items = append(items, &item)
+
+		if p.word.Token != token.COMMA {
+			return items
+		}
+		p.next()
+	}
+}
+
+// Method constraintNames parses ALL keyword or comma-separated list of
+// constraint names in ALTER TABLE ... {CHECK | NOCHECK} CONSTRAINT statement.
+func (p *Parser) constraintNames() []string {
+	names := make([]string, 0, 2)
+
+	for {
+		names = append(names, p.word.Literal)
+		p.next()
+		if p.word.Token != token.COMMA {
+			return names
+		}
+		p.next()
+	}
+}
+
+// Method tableDefinition parses comma-separated list of column definitions
+// and table constraints in parentheses. This method assumes that current word
+// is opening parenthesis.
+func (p *Parser) tableDefinition() *ast.TableDefinition {
+	definition := ast.TableDefinition{}
+	p.next()
+
+	for p.word.Token != token.EOF && p.word.Token != token.GO {
+		definition.Elements = append(definition.Elements, p.tableElement())
+
+		if p.word.Token != token.COMMA {
+			break
+		}
+		p.next()
+	}
+	if p.word.Token == token.RPAREN {
+		p.next()
+	}
+	return &definition
+}
+
+// Method tableElement parses single element of table definition - column
+// definition, table constraint or other element as ast.RawTableElement.
+func (p *Parser) tableElement() ast.TableElement {
+	switch {
+	case p.isConstraintStart():
+		return p.constraint(false)
+	case p.word.Token == token.IDENT &&
+		!(p.isWord("PERIOD") && p.peek().Token == token.FOR):
+		return p.columnDefinition()
+	}
+
+	element := ast.RawTableElement{Words: p.expression(func(w ast.Word) bool {
+		return w.Token == token.COMMA || p.isColumnDefinitionEnd()
+	})}
+	element.Last = p.prev.Pos
+	return &element
+}
+
+// Method columnDefinition parses definition of a single column or computed
+// column.
+func (p *Parser) columnDefinition() *ast.ColumnDefinition {
+	column := ast.ColumnDefinition{Name: p.word.Literal}
+	p.next()
+
+	if p.word.Token == token.AS {
+		p.next()
+		column.Computed = p.expression(func(ast.Word) bool {
+			return p.isColumnOptionStart() || p.isColumnDefinitionEnd()
+		})
+	} else if !p.isColumnOptionStart() && !p.isColumnDefinitionEnd() {
+		column.Type = p.dataType()
+	}
+
+	for !p.isColumnDefinitionEnd() {
+		column.Options = append(column.Options, p.columnOption())
+	}
+	column.Last = p.prev.Pos
+	return &column
+}
+
+// Method columnOption parses single option of column definition. Unknown
+// options (like SPARSE or GENERATED ALWAYS AS ROW START) are parsed as
+// OTHEROPTION till the next option.
+func (p *Parser) columnOption() *ast.ColumnOption {
+	option := ast.ColumnOption{Words: ast.Expression{p.word}}
+
+	switch {
+	case p.isConstraintStart() || p.word.Token == token.REFERENCES ||
+		p.word.Token == token.DEFAULT:
+		return &ast.ColumnOption{
+			Kind:       ast.CONSTRAINTOPTION,
+			Constraint: p.constraint(true),
+		}
+	case p.word.Token == token.NULL:
+		option.Kind = ast.NULLOPTION
+		p.next()
+	case p.word.Token == token.NOT && p.peek().Token == token.NULL:
+		option.Kind = ast.NOTNULLOPTION
+		p.next()
+		option.Words = append(option.Words, p.word)
+		p.next()
+	case p.word.Token == token.IDENTITY:
+		option.Kind = ast.IDENTITYOPTION
+		p.next()
+		if p.word.Token == token.LPAREN {
+			option.Words = append(option.Words, p.parenthesized()...)
+		}
+	case p.word.Token == token.COLLATE:
+		option.Kind = ast.COLLATEOPTION
+		p.next()
+		option.Words = append(option.Words, p.word)
+		p.next()
+	default:
+		option.Kind = ast.OTHEROPTION
+		p.next()
+		option.Words = append(option.Words, p.expression(func(ast.Word) bool {
+			return p.isColumnOptionStart() || p.isColumnDefinitionEnd()
+		})...)
+	}
+	return &option
+}
+
+// Method isColumnOptionStart checks if current word starts an option of
+// column definition.
+func (p *Parser) isColumnOptionStart() bool {
+	switch p.word.Token {
+	case token.CONSTRAINT, token.PRIMARY, token.UNIQUE, token.CHECK,
+		token.REFERENCES, token.FOREIGN, token.DEFAULT, token.NULL,
+		token.IDENTITY, token.COLLATE, token.INDEX:
+		return true
+	case token.NOT:
+		return p.peek().Token == token.NULL
+	}
+
+	for _, option := range []string{"PERSISTED", "SPARSE", "ROWGUIDCOL",
+		"FILESTREAM", "MASKED", "GENERATED", "HIDDEN", "ENCRYPTED"} {
+		if p.isWord(option) {
+			return true
+		}
+	}
+	return false
+}
+
+// Method isColumnDefinitionEnd checks if current word ends column definition
+// (or constraint) either in table definition or in ALTER TABLE statement. It's
+// also used for the end of list items in ALTER TABLE and DROP statements.
+func (p *Parser) isColumnDefinitionEnd() bool {
+	switch p.word.Token {
+	case token.COMMA, token.RPAREN, token.SEMICOLON, token.END, token.EOF,
+		token.GO:
+		return true
+	}
+	return IsOperand(p.prev) && p.isStatementStart()
+}
+
+// Method isConstraintStart checks if current word starts table constraint.
+func (p *Parser) isConstraintStart() bool {
+	switch p.word.Token {
+	case token.CONSTRAINT, token.PRIMARY, token.UNIQUE, token.CHECK,
+		token.FOREIGN:
+		return true
+	}
+	return false
+}
+
+// Method constraint parses column or table constraint. For column
+// constraints (inline is true) constraint ends on the next column option.
+func (p *Parser) constraint(inline bool) *ast.Constraint {
+	constraint := ast.Constraint{}
+	if p.word.Token == token.CONSTRAINT {
+		p.next()
+		constraint.Name = p.word.Literal
+		p.next()
+	}
+
+	switch p.word.Token {
+	case token.PRIMARY, token.UNIQUE:
+		constraint.Kind = ast.UNIQUE
+		if p.word.Token == token.PRIMARY {
+			constraint.Kind = ast.PRIMARYKEY
+			p.next()
+		}
+		p.next()
+		if p.word.Token == token.CLUSTERED || p.word.Token == token.NONCLUSTERED {
+			constraint.Clustered = p.word.Literal
+			p.next()
+		}
+		if p.word.Token == token.LPAREN {
+			constraint.Columns = p.parenList()
+		}
+	case token.FOREIGN, token.REFERENCES:
+		constraint.Kind = ast.REFERENCES
+		if p.word.Token == token.FOREIGN {
+			constraint.Kind = ast.FOREIGNKEY
+			p.next()
+			p.next()
+		}
+		if p.word.Token == token.LPAREN {
+			constraint.Columns = p.parenList()
+		}
+		if p.word.Token == token.REFERENCES {
+			p.next()
+			constraint.References = p.objectName()
+			if p.word.Token == token.LPAREN {
+				constraint.RefColumns = p.nameList()
+			}
+		}
+	case token.CHECK:
+		constraint.Kind = ast.CHECK
+		p.next()
+		if p.word.Token == token.NOT && p.peek().Token == token.FOR {
+			constraint.NotForReplication = true
+			p.next()
+			p.next()
+			p.next()
+		}
+		if p.word.Token == token.LPAREN {
+			constraint.Expr = p.parenthesized()
+		}
+	case token.DEFAULT:
+		constraint.Kind = ast.DEFAULT
+		p.next()
+		constraint.Expr = p.defaultValue()
+	}
+
+	// Options like ON DELETE SET NULL shouldn't be confused with NULL or
+	// DEFAULT column options.
+	constraint.Options = p.expression(func(ast.Word) bool {
+		return p.isColumnDefinitionEnd() ||
+			inline && p.isColumnOptionStart() && !IsWord(p.prev, "SET")
+	})
+	constraint.Last = p.prev.Pos
+	return &constraint
+}
+
+// Method defaultValue parses value of DEFAULT constraint. Value ends on FOR
+// or WITH keyword (in ALTER TABLE ... ADD DEFAULT) or on the next column
+// option, but it contains at least one word, so "DEFAULT NULL" is parsed
+// correctly.
+func (p *Parser) defaultValue() ast.Expression {
+	value := ast.Expression{p.word}
+	if p.word.Token == token.LPAREN {
+		value = p.parenthesized()
+	} else {
+		p.next()
+	}
+
+	return append(value, p.expression(func(w ast.Word) bool {
+		return w.Token == token.FOR || w.Token == token.WITH ||
+			p.isColumnOptionStart() || p.isColumnDefinitionEnd()
+	})...)
+}
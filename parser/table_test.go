@@ -0,0 +1,150 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+	"testing"
+)
+
+// Test for parsing CREATE TABLE with columns, constraints and filegroup.
+func TestParseCreateTable(t *testing.T) {
+	p := testParser(`CREATE TABLE dbo.t (
+		id int IDENTITY(1, 1) NOT NULL CONSTRAINT pk PRIMARY KEY CLUSTERED,
+		name nvarchar(100) COLLATE Latin1_General_CI_AS NULL DEFAULT NULL,
+		parentId int REFERENCES dbo.t (id) ON DELETE SET NULL,
+		total AS price * qty PERSISTED NOT NULL,
+		PERIOD FOR SYSTEM_TIME (validFrom, validTo),
+		CONSTRAINT uq UNIQUE NONCLUSTERED (name ASC, id DESC) WITH (FILLFACTOR = 90),
+		CONSTRAINT fk FOREIGN KEY (parentId, id) REFERENCES dbo.p (a, b),
+		CHECK NOT FOR REPLICATION (id > 0)
+	) ON ps (id) WITH (DATA_COMPRESSION = PAGE);`)
+	stmt := p.statement()
+	table, isTable := stmt.(*ast.CreateTableStatement)
+	if !isTable {
+		t.Fatalf("Expected CREATE TABLE statement, got: %T", stmt)
+	}
+	if table.Name != "dbo.t" || len(table.Definition.Elements) != 8 {
+		t.Fatalf("Expected table dbo.t with 8 elements, got: %v", table)
+	}
+	if len(table.FileGroup) != 4 || len(table.Options) != 6 ||
+		!table.Terminated() {
+		t.Errorf("Unexpected filegroup or options: %v %v", table.FileGroup,
+			table.Options)
+	}
+
+	elements := table.Definition.Elements
+	id := elements[0].(*ast.ColumnDefinition)
+	expKinds := []ast.ColumnOptionKind{ast.IDENTITYOPTION, ast.NOTNULLOPTION,
+		ast.CONSTRAINTOPTION}
+	if len(id.Options) != len(expKinds) {
+		t.Fatalf("Expected %d options of id column, got: %d", len(expKinds),
+			len(id.Options))
+	}
+	for i, option := range id.Options {
+		if option.Kind != expKinds[i] {
+			t.Errorf("Expected option kind %d, got: %d", expKinds[i], option.Kind)
+		}
+	}
+	pk := id.Options[2].Constraint
+	if pk.Name != "pk" || pk.Kind != ast.PRIMARYKEY || pk.Clustered != "CLUSTERED" {
+		t.Errorf("Expected PRIMARY KEY CLUSTERED constraint, got: %v", pk)
+	}
+
+	name := elements[1].(*ast.ColumnDefinition)
+	def := name.Options[2].Constraint
+	if name.Options[0].Kind != ast.COLLATEOPTION || def.Kind != ast.DEFAULT ||
+		def.Expr[0].Token != token.NULL {
+		t.Errorf("Expected COLLATE, NULL and DEFAULT NULL, got: %v", name.Options)
+	}
+
+	parent := elements[2].(*ast.ColumnDefinition)
+	ref := parent.Options[0].Constraint
+	if len(parent.Options) != 1 || ref.Kind != ast.REFERENCES ||
+		ref.References != "dbo.t" || len(ref.Options) != 4 {
+		t.Errorf("Expected REFERENCES with ON DELETE SET NULL, got: %v", ref)
+	}
+
+	total := elements[3].(*ast.ColumnDefinition)
+	if total.Type != nil || len(total.Computed) != 3 || len(total.Options) != 2 {
+		t.Errorf("Expected computed column, got: %v", total)
+	}
+	if _, isRaw := elements[4].(*ast.RawTableElement); !isRaw {
+		t.Errorf("Expected PERIOD FOR SYSTEM_TIME as raw element")
+	}
+
+	uq := elements[5].(*ast.Constraint)
+	if uq.Kind != ast.UNIQUE || len(uq.Columns) != 2 || len(uq.Options) != 6 {
+		t.Errorf("Expected UNIQUE constraint with options, got: %v", uq)
+	}
+	fk := elements[6].(*ast.Constraint)
+	if fk.Kind != ast.FOREIGNKEY || len(fk.Columns) != 2 ||
+		len(fk.RefColumns) != 2 {
+		t.Errorf("Expected FOREIGN KEY constraint, got: %v", fk)
+	}
+	check := elements[7].(*ast.Constraint)
+	if check.Kind != ast.CHECK || !check.NotForReplication ||
+		len(check.Expr) != 5 {
+		t.Errorf("Expected CHECK NOT FOR REPLICATION, got: %v", check)
+	}
+	for id, element := range elements[:len(elements)-1] {
+		if element.End().Offset >= elements[id+1].End().Offset {
+			t.Errorf("Expected elements ending in order, got: %v and %v",
+				element.End(), elements[id+1].End())
+		}
+	}
+}
+
+// Test for parsing ALTER TABLE statements.
+func TestParseAlterTable(t *testing.T) {
+	p := testParser(`ALTER TABLE dbo.t ADD a int NULL, CONSTRAINT df DEFAULT 0 FOR b
+	ALTER TABLE dbo.t ALTER COLUMN a bigint NOT NULL
+	ALTER TABLE dbo.t DROP CONSTRAINT IF EXISTS pk WITH (ONLINE = ON), x,
+		COLUMN c
+	ALTER TABLE dbo.t WITH NOCHECK CHECK CONSTRAINT ALL
+	ALTER TABLE dbo.t NOCHECK CONSTRAINT a, b;
+	ALTER TABLE dbo.t ENABLE TRIGGER a, b`)
+	alters := make([]*ast.AlterTableStatement, 6)
+	for id := range alters {
+		stmt := p.statement()
+		alter, isAlter := stmt.(*ast.AlterTableStatement)
+		if !isAlter {
+			t.Fatalf("Expected ALTER TABLE statement, got: %T", stmt)
+		}
+		alters[id] = alter
+	}
+
+	if alters[0].Action != ast.ADDACTION || len(alters[0].Elements) != 2 {
+		t.Errorf("Expected ADD of 2 elements, got: %v", alters[0])
+	}
+	def := alters[0].Elements[1].(*ast.Constraint)
+	if def.Kind != ast.DEFAULT || len(def.Expr) != 1 || len(def.Options) != 2 {
+		t.Errorf("Expected DEFAULT 0 FOR b, got: %v", def)
+	}
+	column := alters[1].Column
+	if alters[1].Action != ast.ALTERCOLUMNACTION || column.Name != "a" ||
+		column.Type.Name != "bigint" || len(column.Options) != 1 {
+		t.Errorf("Expected ALTER COLUMN a bigint NOT NULL, got: %v", column)
+	}
+	drops := alters[2].Drops
+	if alters[2].Action != ast.DROPACTION || len(drops) != 3 {
+		t.Fatalf("Expected DROP of 3 items, got: %v", drops)
+	}
+	if !drops[0].IfExists || drops[0].Kind != "CONSTRAINT" ||
+		len(drops[0].Options) != 6 || drops[1].Kind != "" ||
+		drops[2].Kind != "COLUMN" {
+		t.Errorf("Unexpected dropped items: %v %v %v", drops[0], drops[1],
+			drops[2])
+	}
+	if alters[3].With != "NOCHECK" || alters[3].Action != ast.CHECKACTION ||
+		alters[3].Constraints[0] != "ALL" {
+		t.Errorf("Expected WITH NOCHECK CHECK CONSTRAINT ALL, got: %v",
+			alters[3])
+	}
+	if alters[4].Action != ast.NOCHECKACTION ||
+		len(alters[4].Constraints) != 2 || !alters[4].Terminated() {
+		t.Errorf("Expected NOCHECK CONSTRAINT a, b, got: %v", alters[4])
+	}
+	if alters[5].Action != ast.OTHERACTION || len(alters[5].Words) != 5 {
+		t.Errorf("Expected ENABLE TRIGGER a, b, got: %v", alters[5].Words)
+	}
+}
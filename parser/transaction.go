@@ -0,0 +1,138 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+)
+
+// Method tryCatchStatement parses BEGIN TRY ... END TRY BEGIN CATCH ... END
+// CATCH construct. This method assumes that current word is BEGIN followed by
+// TRY.
+func (p *Parser) tryCatchStatement() *ast.TryCatchStatement {
+	stmt := ast.TryCatchStatement{}
+	p.next()
+	p.next()
+	stmt.Try = p.statementList(func() bool {
+		return p.word.Token == token.END
+	})
+	if p.word.Token == token.END && IsWord(p.peek(), "TRY") {
+		p.next()
+		p.next()
+	}
+
+	if p.word.Token == token.BEGIN && IsWord(p.peek(), "CATCH") {
+		p.next()
+		p.next()
+		stmt.Catch = p.statementList(func() bool {
+			return p.word.Token == token.END
+		})
+		if p.word.Token == token.END && IsWord(p.peek(), "CATCH") {
+			p.next()
+			p.next()
+		}
+	}
+	return &stmt
+}
+
+// Method isTransaction checks if current word starts BEGIN, COMMIT, ROLLBACK
+// or SAVE TRANSACTION statement.
+func (p *Parser) isTransaction() bool {
+	next := p.peek()
+	tran := IsWord(next, "TRAN") || IsWord(next, "TRANSACTION")
+
+	switch {
+	case p.word.Token == token.BEGIN:
+		return tran || IsWord(next, "DISTRIBUTED")
+	case p.isWord("COMMIT"), p.isWord("ROLLBACK"):
+		return true
+	case p.isWord("SAVE"):
+		return tran
+	}
+	return false
+}
+
+// Method transactionStatement parses BEGIN, COMMIT, ROLLBACK and SAVE
+// TRANSACTION statements. This method assumes that isTransaction returned
+// true.
+func (p *Parser) transactionStatement() *ast.TransactionStatement {
+	stmt := ast.TransactionStatement{}
+	switch {
+	case p.word.Token == token.BEGIN:
+		stmt.Kind = ast.BEGINTRAN
+	case p.isWord("COMMIT"):
+		stmt.Kind = ast.COMMITTRAN
+	case p.isWord("ROLLBACK"):
+		stmt.Kind = ast.ROLLBACKTRAN
+	default:
+		stmt.Kind = ast.SAVETRAN
+	}
+	p.next()
+
+	if p.isWord("DISTRIBUTED") {
+		stmt.Distributed = true
+		p.next()
+	}
+	if p.isWord("TRAN") || p.isWord("TRANSACTION") || p.isWord("WORK") {
+		stmt.Keyword = p.word.Literal
+		p.next()
+		if p.word.Token == token.IDENT && !p.isStatementStart() {
+			stmt.Name = p.word.Literal
+			p.next()
+		}
+	}
+
+	if p.word.Token == token.WITH && IsWord(p.peek(), "MARK") {
+		stmt.Mark = true
+		p.next()
+		p.next()
+		if p.word.Token == token.STRING {
+			stmt.Description = p.word.Literal
+			p.next()
+		}
+	}
+	if p.word.Token == token.WITH && p.peek().Token == token.LPAREN {
+		stmt.Options = ast.Expression{p.word}
+		p.next()
+		stmt.Options = append(stmt.Options, p.parenthesized()...)
+	}
+	return &stmt
+}
+
+// Method throwStatement parses THROW statement with optional arguments. This
+// method assumes that current word is THROW.
+func (p *Parser) throwStatement() *ast.ThrowStatement {
+	stmt := ast.ThrowStatement{}
+	p.next()
+
+	for !p.isColumnDefinitionEnd() && !p.isStatementStart() {
+		stmt.Args = append(stmt.Args, p.expression(func(ast.Word) bool {
+			return p.isColumnDefinitionEnd()
+		}))
+		if p.word.Token != token.COMMA {
+			break
+		}
+		p.next()
+	}
+	return &stmt
+}
+
+// Method raiserrorStatement parses RAISERROR statement. This method assumes
+// that current word is RAISERROR followed by opening parenthesis.
+func (p *Parser) raiserrorStatement() *ast.RaiserrorStatement {
+	stmt := ast.RaiserrorStatement{}
+	p.next()
+	stmt.Args = p.parenList()
+
+	if p.word.Token == token.WITH {
+		p.next()
+		for {
+			stmt.Options = append(stmt.Options, p.word.Literal)
+			p.next()
+			if p.word.Token != token.COMMA {
+				break
+			}
+			p.next()
+		}
+	}
+	return &stmt
+}
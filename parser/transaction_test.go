@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"testing"
+)
+
+// Test for parsing TRY ... CATCH with transaction error handling.
+func TestParseTryCatch(t *testing.T) {
+	p := testParser(`BEGIN TRY
+		BEGIN TRAN
+		UPDATE t SET a = 1
+		COMMIT
+	END TRY
+	BEGIN CATCH
+		IF @@TRANCOUNT > 0 ROLLBACK;
+		THROW;
+	END CATCH`)
+
+	stmt, isTry := p.statement().(*ast.TryCatchStatement)
+	if !isTry {
+		t.Fatalf("Expected TRY ... CATCH statement")
+	}
+	if len(stmt.Try) != 3 || len(stmt.Catch) != 2 {
+		t.Fatalf("Expected 3 statements in TRY and 2 in CATCH, got: %d, %d",
+			len(stmt.Try), len(stmt.Catch))
+	}
+	if commit, isTran := stmt.Try[2].(*ast.TransactionStatement); !isTran ||
+		commit.Kind != ast.COMMITTRAN || commit.Keyword != "" {
+		t.Errorf("Expected COMMIT, got: %v", stmt.Try[2])
+	}
+	rollback := stmt.Catch[0].(*ast.IfStatement).Then
+	if !rollback.Terminated() {
+		t.Errorf("Expected terminated ROLLBACK, got: %v", rollback)
+	}
+	if throw, isThrow := stmt.Catch[1].(*ast.ThrowStatement); !isThrow ||
+		throw.Args != nil || !throw.Terminated() {
+		t.Errorf("Expected THROW without arguments, got: %v", stmt.Catch[1])
+	}
+}
+
+// Test for parsing transaction statements.
+func TestParseTransaction(t *testing.T) {
+	p := testParser(`BEGIN DISTRIBUTED TRANSACTION
+	BEGIN TRAN @name WITH MARK 'desc'
+	SAVE TRAN sp
+	ROLLBACK TRANSACTION sp
+	COMMIT WORK
+	COMMIT TRAN t WITH (DELAYED_DURABILITY = ON)
+	ROLLBACK TRAN
+	PRINT 'x'`)
+	expKinds := []ast.TransactionKind{ast.BEGINTRAN, ast.BEGINTRAN,
+		ast.SAVETRAN, ast.ROLLBACKTRAN, ast.COMMITTRAN, ast.COMMITTRAN,
+		ast.ROLLBACKTRAN}
+	expNames := []string{"", "@name", "sp", "sp", "", "t", ""}
+	expKeywords := []string{"TRANSACTION", "TRAN", "TRAN", "TRANSACTION",
+		"WORK", "TRAN", "TRAN"}
+
+	trans := make([]*ast.TransactionStatement, len(expKinds))
+	for id := range expKinds {
+		stmt, isTran := p.statement().(*ast.TransactionStatement)
+		if !isTran {
+			t.Fatalf("Expected transaction statement %d", id)
+		}
+		if stmt.Kind != expKinds[id] || stmt.Name != expNames[id] ||
+			stmt.Keyword != expKeywords[id] {
+			t.Errorf("Unexpected transaction statement %d: %v", id, stmt)
+		}
+		trans[id] = stmt
+	}
+
+	if !trans[0].Distributed {
+		t.Errorf("Expected distributed transaction")
+	}
+	if !trans[1].Mark || trans[1].Description != "'desc'" {
+		t.Errorf("Expected WITH MARK 'desc', got: %v", trans[1])
+	}
+	if len(trans[5].Options) != 6 {
+		t.Errorf("Expected WITH (DELAYED_DURABILITY = ON), got: %v",
+			trans[5].Options)
+	}
+	if _, isRaw := p.statement().(*ast.RawStatement); !isRaw {
+		t.Errorf("Expected PRINT as raw statement")
+	}
+}
+
+// Test for parsing THROW and RAISERROR statements.
+func TestParseThrowAndRaiserror(t *testing.T) {
+	p := testParser(`THROW 50000, N'Error: ' + @msg, 1
+	RAISERROR('%s', 16, 1, @a) WITH NOWAIT, LOG
+	RAISERROR 50001 'old syntax'`)
+
+	throw, isThrow := p.statement().(*ast.ThrowStatement)
+	if !isThrow || len(throw.Args) != 3 || len(throw.Args[1]) != 3 {
+		t.Errorf("Expected THROW with 3 arguments, got: %v", throw)
+	}
+	raise, isRaise := p.statement().(*ast.RaiserrorStatement)
+	if !isRaise || len(raise.Args) != 4 || len(raise.Options) != 2 {
+		t.Errorf("Expected RAISERROR with options, got: %v", raise)
+	}
+	if _, isRaw := p.statement().(*ast.RawStatement); !isRaw {
+		t.Errorf("Expected old RAISERROR syntax as raw statement")
+	}
+}
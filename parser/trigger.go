@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+)
+
+// Method trigger parses CREATE/ALTER TRIGGER statement. This method assumes
+// that CREATE, ALTER or CREATE OR ALTER was already parsed and current word
+// is TRIGGER.
+func (p *Parser) trigger(mode ast.CreateMode) *ast.TriggerStatement {
+	trigger := ast.TriggerStatement{Mode: mode}
+	p.next()
+	trigger.Name = p.objectName()
+
+	if p.word.Token == token.ON {
+		p.next()
+		if p.word.Token == token.ALL {
+			trigger.AllServer = true
+			p.next()
+			p.next()
+		} else {
+			trigger.Target = p.objectName()
+		}
+	}
+	if p.word.Token == token.WITH {
+		trigger.Options = p.optionList()
+	}
+
+	trigger.Timing = p.triggerTiming()
+	trigger.Events = p.triggerEvents()
+
+	if p.word.Token == token.WITH && IsWord(p.peek(), "APPEND") {
+		trigger.Append = true
+		p.next()
+		p.next()
+	}
+	if p.word.Token == token.NOT && p.peek().Token == token.FOR {
+		trigger.NotForReplication = true
+		p.next()
+		p.next()
+		p.next()
+	}
+	if p.word.Token == token.AS {
+		p.next()
+	}
+
+	trigger.Body = p.statementList(func() bool { return false })
+	return &trigger
+}
+
+// Method triggerTiming parses FOR, AFTER or INSTEAD OF keywords of trigger
+// definition.
+func (p *Parser) triggerTiming() ast.TriggerTiming {
+	switch {
+	case p.isWord("AFTER"):
+		p.next()
+		return ast.AFTER
+	case p.isWord("INSTEAD"):
+		p.next()
+		p.next()
+		return ast.INSTEADOF
+	}
+
+	if p.word.Token == token.FOR {
+		p.next()
+	}
+	return ast.FOR
+}
+
+// Method triggerEvents parses comma-separated list of events which fire the
+// trigger, like "INSERT, UPDATE" or "CREATE_TABLE, ALTER_TABLE".
+func (p *Parser) triggerEvents() []ast.Word {
+	events := make([]ast.Word, 0, 3)
+
+	for p.word.Token != token.EOF && p.word.Token != token.GO {
+		events = append(events, p.word)
+		p.next()
+		if p.word.Token != token.COMMA {
+			break
+		}
+		p.next()
+	}
+	return events
+}
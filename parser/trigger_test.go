@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+	"testing"
+)
+
+// Test for parsing DML trigger.
+func TestParseDMLTrigger(t *testing.T) {
+	p := testParser(`CREATE TRIGGER dbo.trT ON dbo.t
+	WITH ENCRYPTION, EXECUTE AS CALLER
+	INSTEAD OF INSERT, UPDATE, DELETE
+	NOT FOR REPLICATION
+	AS
+	BEGIN
+		SET NOCOUNT ON;
+		INSERT INTO dbo.t SELECT * FROM inserted
+	END`)
+	trigger := parseTrigger(t, p)
+
+	if trigger.Name != "dbo.trT" || trigger.Target != "dbo.t" ||
+		len(trigger.Options) != 2 {
+		t.Errorf("Unexpected trigger definition: %v", trigger)
+	}
+	if trigger.Timing != ast.INSTEADOF || len(trigger.Events) != 3 ||
+		trigger.Events[2].Token != token.DELETE {
+		t.Errorf("Expected INSTEAD OF INSERT, UPDATE, DELETE, got: %d %v",
+			trigger.Timing, trigger.Events)
+	}
+	if !trigger.NotForReplication || len(trigger.Body) != 1 {
+		t.Errorf("Expected NOT FOR REPLICATION and single statement body")
+	}
+	block := trigger.Body[0].(*ast.BlockStatement)
+	if len(block.Statements) != 2 {
+		t.Errorf("Expected 2 statements in trigger body, got: %d",
+			len(block.Statements))
+	}
+}
+
+// Test for parsing DDL trigger.
+func TestParseDDLTrigger(t *testing.T) {
+	p := testParser(`ALTER TRIGGER trDDL ON ALL SERVER FOR CREATE_TABLE,
+	ALTER_TABLE AS PRINT 'x'`)
+	trigger := parseTrigger(t, p)
+
+	if trigger.Mode != ast.ALTER || !trigger.AllServer ||
+		trigger.Timing != ast.FOR {
+		t.Errorf("Expected ALTER TRIGGER ON ALL SERVER FOR, got: %v", trigger)
+	}
+	if len(trigger.Events) != 2 || trigger.Events[1].Literal != "ALTER_TABLE" {
+		t.Errorf("Expected 2 DDL events, got: %v", trigger.Events)
+	}
+	if len(trigger.Body) != 1 {
+		t.Errorf("Expected single statement body, got: %d", len(trigger.Body))
+	}
+}
+
+// Function parseTrigger parses single statement and checks that it's a
+// trigger.
+func parseTrigger(t *testing.T, p *Parser) *ast.TriggerStatement {
+	t.Helper()
+	stmt := p.statement()
+	trigger, isTrigger := stmt.(*ast.TriggerStatement)
+	if !isTrigger {
+		t.Fatalf("Expected trigger statement, got: %T", stmt)
+	}
+	return trigger
+}
@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+)
+
+// Method updateStatement parses UPDATE statement. Statements without SET
+// clause (like UPDATE STATISTICS) are parsed as ast.RawStatement. This method
+// assumes that current word is UPDATE.
+func (p *Parser) updateStatement() ast.Statement {
+	state := p.save()
+	p.next()
+	stmt := ast.UpdateStatement{}
+	if !p.isWord("STATISTICS") {
+		stmt.Target = p.expression(func(w ast.Word) bool {
+			return IsWord(w, "SET") || w.Token == token.SEMICOLON ||
+				IsOperand(p.prev) && p.isStatementStart()
+		})
+	}
+	if len(stmt.Target) == 0 || !p.isWord("SET") {
+		p.restore(state)
+		return p.rawStatement()
+	}
+
+	p.next()
+	for {
+		stmt.Assignments = append(stmt.Assignments, p.assignment())
+		if p.word.Token != token.COMMA {
+			break
+		}
+		p.next()
+	}
+	if p.isWord("OUTPUT") {
+		p.next()
+		stmt.Output = p.expression(func(w ast.Word) bool {
+			return w.Token != token.INTO && p.isClauseEnd()
+		})
+	}
+
+	query := ast.SelectQuery{}
+	p.selectFrom(&query)
+	p.selectWhere(&query)
+	p.selectOptions(&query)
+	stmt.From, stmt.Where, stmt.Options = query.From, query.Where,
+		query.Options
+	return &stmt
+}
+
+// Method assignment parses single item of SET clause of UPDATE statement.
+func (p *Parser) assignment() *ast.Assignment {
+	end := func(w ast.Word) bool {
+		return w.Token == token.COMMA || p.isClauseEnd() ||
+			IsWord(w, "OUTPUT") && IsOperand(p.prev)
+	}
+	assignment := ast.Assignment{Target: p.expression(func(w ast.Word) bool {
+		return end(w) || p.isAssignment(0)
+	})}
+
+	if p.isAssignment(0) {
+		for p.word.Token != token.ASSIGN {
+			assignment.Operator += p.word.Literal
+			p.next()
+		}
+		assignment.Operator += p.word.Literal
+		p.next()
+		assignment.Value = p.expression(end)
+	}
+	assignment.End = p.prev.Pos
+	return &assignment
+}
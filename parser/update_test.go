@@ -0,0 +1,58 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"testing"
+)
+
+// Test for parsing UPDATE statement with all supported clauses.
+func TestParseUpdate(t *testing.T) {
+	p := testParser(`UPDATE TOP (10) o WITH (ROWLOCK)
+	SET Qty += 1, @old = Price = Price * 2, Doc.Modify(1)
+	OUTPUT inserted.Qty INTO @t
+	FROM dbo.Orders o JOIN dbo.Items i ON i.Id = o.ItemId
+	WHERE i.Active = 1
+	OPTION (MAXDOP 1)
+	SELECT 1`)
+
+	update, isUpdate := p.statement().(*ast.UpdateStatement)
+	if !isUpdate {
+		t.Fatalf("Expected UPDATE statement")
+	}
+	if len(update.Target) != 9 || len(update.Assignments) != 3 {
+		t.Fatalf("Unexpected UPDATE statement: %v", update)
+	}
+	qty, old, doc := update.Assignments[0], update.Assignments[1],
+		update.Assignments[2]
+	if len(qty.Target) != 1 || qty.Operator != "+=" || len(qty.Value) != 1 {
+		t.Errorf("Expected Qty += 1, got: %v", qty)
+	}
+	if len(old.Target) != 1 || old.Operator != "=" || len(old.Value) != 5 {
+		t.Errorf("Expected @old = Price = Price * 2, got: %v", old)
+	}
+	if len(doc.Target) != 6 || doc.Operator != "" || doc.Value != nil {
+		t.Errorf("Expected method call without operator, got: %v", doc)
+	}
+	if len(update.Output) != 5 {
+		t.Errorf("Expected OUTPUT clause of 5 words, got: %v", update.Output)
+	}
+	if update.From == nil || len(update.From.Joins) != 1 ||
+		update.Where == nil || update.Options == nil {
+		t.Errorf("Expected FROM, WHERE and OPTION clauses")
+	}
+	if _, isSelect := p.statement().(*ast.SelectStatement); !isSelect {
+		t.Errorf("Expected SELECT statement after UPDATE")
+	}
+}
+
+// Test for parsing UPDATE STATISTICS as raw statement.
+func TestParseUpdateStatistics(t *testing.T) {
+	p := testParser("UPDATE STATISTICS dbo.Orders\nSELECT 1")
+
+	if _, isRaw := p.statement().(*ast.RawStatement); !isRaw {
+		t.Errorf("Expected UPDATE STATISTICS to be raw statement")
+	}
+	if _, isSelect := p.statement().(*ast.SelectStatement); !isSelect {
+		t.Errorf("Expected SELECT statement after UPDATE STATISTICS")
+	}
+}
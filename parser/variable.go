@@ -0,0 +1,135 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+)
+
+// Method declareStatement parses DECLARE statement of local variables or
+// declaration of a named cursor. Statements with incomplete declarations of
+// table variables are parsed as ast.RawStatement. This method assumes that
+// current word is DECLARE.
+func (p *Parser) declareStatement() ast.Statement {
+	if !isVariable(p.peek()) {
+		return p.declareCursor()
+	}
+
+	stmt := ast.DeclareStatement{}
+	state := p.save()
+	p.next()
+	for isVariable(p.word) {
+		variable := p.variableDeclaration()
+		if variable == nil {
+			p.restore(state)
+			return p.rawStatement()
+		}
+		stmt.Variables = append(stmt.Variables, variable)
+		if p.word.Token != token.COMMA {
+			break
+		}
+		p.next()
+	}
+	return &stmt
+}
+
+// Method variableDeclaration parses declaration of a single variable of the
+// form "@name [AS] { data_type [= value] | TABLE (...) }". It returns nil
+// when TABLE isn't followed by complete table definition.
+func (p *Parser) variableDeclaration() *ast.VariableDeclaration {
+	variable := ast.VariableDeclaration{Name: p.word.Literal}
+	p.next()
+
+	if p.word.Token == token.AS {
+		variable.ASKeyword = true
+		p.next()
+	}
+	if p.word.Token == token.TABLE {
+		p.next()
+		if p.word.Token != token.LPAREN {
+			return nil
+		}
+		variable.Table = p.tableDefinition()
+		if p.prev.Token != token.RPAREN {
+			return nil
+		}
+		variable.End = p.prev.Pos
+		return &variable
+	}
+
+	variable.Type = p.dataType()
+	if p.word.Token == token.ASSIGN {
+		p.next()
+		variable.Value = p.expression(func(ast.Word) bool {
+			return p.isColumnDefinitionEnd()
+		})
+	}
+	variable.End = p.prev.Pos
+	return &variable
+}
+
+// Method setStatement parses SET statement which assigns a value to variable
+// or changes session options. Other forms (like SET @xml.modify(...)) are
+// parsed as ast.RawStatement. This method assumes that current word is SET.
+func (p *Parser) setStatement() ast.Statement {
+	next := p.peek()
+	if !isVariable(next) {
+		return p.setOptionStatement()
+	}
+	if !p.isAssignment(2) {
+		return p.rawStatement()
+	}
+
+	p.next()
+	stmt := ast.SetStatement{Variable: p.word.Literal}
+	p.next()
+	for p.word.Token != token.ASSIGN {
+		stmt.Operator += p.word.Literal
+		p.next()
+	}
+	stmt.Operator += p.word.Literal
+	p.next()
+
+	stmt.Value = p.expression(func(ast.Word) bool {
+		return p.isColumnDefinitionEnd() && p.word.Token != token.COMMA
+	})
+	return &stmt
+}
+
+// Method isAssignment checks if n-th next word (or current word for zero n)
+// is an assignment operator, either "=" or compound operator like "+=".
+func (p *Parser) isAssignment(n int) bool {
+	w := p.word
+	if n > 0 {
+		w = p.peekN(n)
+	}
+	if w.Token == token.ASSIGN {
+		return true
+	}
+	switch w.Literal {
+	case "+", "-", "*", "/", "%", "&", "^", "|":
+		return p.peekN(n+1).Token == token.ASSIGN
+	}
+	return false
+}
+
+// Method setOptionStatement parses SET statement which changes session
+// options. Values of options (like ON) don't end statements, so option ends on
+// any word which starts a new statement. This method assumes that current
+// word is SET.
+func (p *Parser) setOptionStatement() *ast.SetOptionStatement {
+	stmt := ast.SetOptionStatement{}
+	p.next()
+
+	for {
+		stmt.Options = append(stmt.Options, p.word.Literal)
+		p.next()
+		if p.word.Token != token.COMMA {
+			break
+		}
+		p.next()
+	}
+	stmt.Value = p.expression(func(ast.Word) bool {
+		return p.isColumnDefinitionEnd() || p.isStatementStart()
+	})
+	return &stmt
+}
@@ -0,0 +1,108 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"testing"
+)
+
+// Test for parsing DECLARE statements.
+func TestParseDeclare(t *testing.T) {
+	p := testParser(`DECLARE @a int = 1, @b AS nvarchar(max), @c CURSOR
+	DECLARE @t TABLE (id int NOT NULL, v varchar(10))
+	DECLARE c CURSOR FOR SELECT 1`)
+
+	stmt, isDeclare := p.statement().(*ast.DeclareStatement)
+	if !isDeclare || len(stmt.Variables) != 3 {
+		t.Fatalf("Expected DECLARE of 3 variables, got: %v", stmt)
+	}
+	a, b, c := stmt.Variables[0], stmt.Variables[1], stmt.Variables[2]
+	if a.Name != "@a" || a.Type.Name != "int" || len(a.Value) != 1 {
+		t.Errorf("Expected @a int = 1, got: %v", a)
+	}
+	if !b.ASKeyword || b.Type.Name != "nvarchar" || b.Value != nil {
+		t.Errorf("Expected @b AS nvarchar(max), got: %v", b)
+	}
+	if c.Type.Name != "CURSOR" {
+		t.Errorf("Expected cursor variable, got: %v", c.Type)
+	}
+
+	stmt = p.statement().(*ast.DeclareStatement)
+	table := stmt.Variables[0]
+	if table.Type != nil || table.Table == nil ||
+		len(table.Table.Elements) != 2 {
+		t.Errorf("Expected table variable with 2 columns, got: %v", table)
+	}
+	if _, isCursor := p.statement().(*ast.DeclareCursorStatement); !isCursor {
+		t.Errorf("Expected cursor declaration")
+	}
+}
+
+// Test for parsing DECLARE statements with incomplete table variables as raw
+// statements.
+func TestParseDeclareTruncated(t *testing.T) {
+	tests := []string{"DECLARE @t TABLE", "DECLARE @a int, @t TABLE (a int",
+		"DECLARE @t AS TABLE SELECT 1"}
+
+	for _, src := range tests {
+		p := testParser(src)
+		if _, isRaw := p.statement().(*ast.RawStatement); !isRaw {
+			t.Errorf("Expected raw statement for %q", src)
+		}
+	}
+}
+
+// Test for parsing SET statements of variables and options.
+func TestParseSet(t *testing.T) {
+	p := testParser(`SET @a = (SELECT MAX(x) FROM t)
+	SET @i += 1
+	SET NOCOUNT ON
+	SET ANSI_NULLS, QUOTED_IDENTIFIER OFF;
+	SET TRANSACTION ISOLATION LEVEL READ UNCOMMITTED
+	SET @x.modify('delete /a')`)
+
+	set, isSet := p.statement().(*ast.SetStatement)
+	if !isSet || set.Variable != "@a" || set.Operator != "=" ||
+		len(set.Value) != 9 {
+		t.Errorf("Expected SET @a = (SELECT ...), got: %v", set)
+	}
+	set = p.statement().(*ast.SetStatement)
+	if set.Operator != "+=" || len(set.Value) != 1 {
+		t.Errorf("Expected SET @i += 1, got: %v", set)
+	}
+
+	expOptions := [][]string{{"NOCOUNT"}, {"ANSI_NULLS", "QUOTED_IDENTIFIER"},
+		{"TRANSACTION"}}
+	expValues := []int{1, 1, 4}
+	for id, options := range expOptions {
+		option, isOption := p.statement().(*ast.SetOptionStatement)
+		if !isOption {
+			t.Fatalf("Expected SET option statement %d", id)
+		}
+		if len(option.Options) != len(options) ||
+			option.Options[0] != options[0] ||
+			len(option.Value) != expValues[id] {
+			t.Errorf("Unexpected SET option statement: %v", option)
+		}
+	}
+
+	if _, isRaw := p.statement().(*ast.RawStatement); !isRaw {
+		t.Errorf("Expected method call as raw statement")
+	}
+}
+
+// Test for parsing SELECT statement which assigns values to variables.
+func TestParseSelectAssignment(t *testing.T) {
+	p := testParser(`SELECT @a = MAX(x), @b = 'x' FROM t SET @c = 1`)
+
+	stmt, isSelect := p.statement().(*ast.SelectStatement)
+	if !isSelect {
+		t.Fatalf("Expected SELECT statement")
+	}
+	cols := stmt.Query.Columns
+	if len(cols) != 2 || len(cols[0]) != 6 || len(cols[1]) != 3 {
+		t.Errorf("Expected 2 assignments, got: %v", cols)
+	}
+	if _, isSet := p.statement().(*ast.SetStatement); !isSet {
+		t.Errorf("Expected SET statement after SELECT")
+	}
+}
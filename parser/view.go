@@ -0,0 +1,37 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+)
+
+// Method view parses CREATE/ALTER VIEW statement. This method assumes that
+// CREATE, ALTER or CREATE OR ALTER was already parsed and current word is
+// VIEW. View body is parsed by SELECT statement parser.
+func (p *Parser) view(mode ast.CreateMode) *ast.ViewStatement {
+	view := ast.ViewStatement{Mode: mode}
+	p.next()
+	view.Name = p.objectName()
+
+	if p.word.Token == token.LPAREN {
+		view.Columns = p.nameList()
+	}
+	if p.word.Token == token.WITH {
+		view.Options = p.optionList()
+	}
+	if p.word.Token == token.AS {
+		p.next()
+	}
+
+	if p.word.Token == token.SELECT || p.isSelectWith() {
+		view.Query = p.selectStatement()
+	}
+	if p.word.Token == token.WITH && p.peek().Token == token.CHECK &&
+		p.peekN(2).Token == token.OPTION {
+		view.CheckOption = true
+		p.next()
+		p.next()
+		p.next()
+	}
+	return &view
+}
@@ -0,0 +1,36 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"testing"
+)
+
+// Test for parsing CREATE/ALTER VIEW statements.
+func TestParseView(t *testing.T) {
+	p := testParser(`CREATE OR ALTER VIEW dbo.v (a, b)
+	WITH SCHEMABINDING, VIEW_METADATA
+	AS
+	WITH c AS (SELECT 1 AS a, 2 AS b) SELECT a, b FROM c
+	WITH CHECK OPTION;
+	ALTER VIEW v AS SELECT 1 AS x`)
+
+	view, isView := p.statement().(*ast.ViewStatement)
+	if !isView {
+		t.Fatalf("Expected view statement")
+	}
+	if view.Mode != ast.CREATEORALTER || view.Name != "dbo.v" ||
+		len(view.Columns) != 2 || len(view.Options) != 2 {
+		t.Errorf("Unexpected view definition: %v", view)
+	}
+	if view.Query == nil || len(view.Query.With) != 1 {
+		t.Errorf("Expected SELECT query with CTE, got: %v", view.Query)
+	}
+	if !view.CheckOption || !view.Terminated() {
+		t.Errorf("Expected WITH CHECK OPTION and semicolon")
+	}
+
+	view = p.statement().(*ast.ViewStatement)
+	if view.Mode != ast.ALTER || len(view.Query.Query.Columns) != 1 {
+		t.Errorf("Unexpected second view: %v", view)
+	}
+}
@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+)
+
+// Method selectWindow parses WINDOW clause of SELECT query with named window
+// definitions.
+func (p *Parser) selectWindow(selectTree *ast.SelectQuery) {
+	if !p.isWindowClause() {
+		return
+	}
+
+	p.next()
+	for {
+		window := ast.WindowDefinition{Name: p.word.Literal}
+		p.next()
+		p.next()
+		window.Spec = p.windowSpec()
+		(*selectTree).Window = append((*selectTree).Window, &window)
+
+		if p.word.Token != token.COMMA {
+			return
+		}
+		p.next()
+	}
+}
+
+// Method isWindowClause checks if current word starts WINDOW clause. WINDOW
+// isn't a reserved keyword, so it's recognized only when it's followed by
+// "name AS (".
+func (p *Parser) isWindowClause() bool {
+	return p.isWord("WINDOW") && p.peek().Token == token.IDENT &&
+		p.peekN(2).Token == token.AS && p.peekN(3).Token == token.LPAREN
+}
+
+// Method windowSpec parses window specification in parentheses. End of the
+// specification is its closing parenthesis. This method assumes that current
+// word is opening parenthesis.
+func (p *Parser) windowSpec() *ast.WindowSpec {
+	spec := ast.WindowSpec{}
+	p.next()
+
+	if p.word.Token == token.IDENT && !p.isFrameStart() {
+		spec.Name = p.word.Literal
+		p.next()
+	}
+	if p.word.Token == token.PARTITIONBY {
+		p.next()
+		spec.PartitionBy = p.windowItems()
+	}
+	if p.word.Token == token.ORDERBY {
+		p.next()
+		spec.OrderBy = p.windowItems()
+	}
+	if p.isFrameStart() {
+		spec.Frame = p.expression(func(ast.Word) bool { return false })
+	}
+
+	if p.word.Token == token.RPAREN {
+		p.next()
+	}
+	spec.End = p.prev.Pos
+	return &spec
+}
+
+// Method windowItems parses comma-separated items of PARTITION BY or ORDER BY
+// clause in window specification.
+func (p *Parser) windowItems() []ast.Expression {
+	items := make([]ast.Expression, 0, 2)
+
+	for {
+		items = append(items, p.expression(func(w ast.Word) bool {
+			return w.Token == token.COMMA || w.Token == token.ORDERBY ||
+				p.isFrameStart()
+		}))
+		if p.word.Token != token.COMMA {
+			return items
+		}
+		p.next()
+	}
+}
+
+// Method isFrameStart checks if current word starts ROWS or RANGE clause of
+// window specification.
+func (p *Parser) isFrameStart() bool {
+	return p.word.Token == token.ROWS || p.isWord("RANGE")
+}
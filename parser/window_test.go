@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"mssfmt/ast"
+	"testing"
+)
+
+// Test for parsing window functions with OVER and WITHIN GROUP clauses.
+func TestParseWindowFunctions(t *testing.T) {
+	p := testParser(`SELECT ROW_NUMBER() OVER (PARTITION BY a, b ORDER BY c DESC
+		ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) AS rn,
+		STRING_AGG(x, ',') WITHIN GROUP (ORDER BY x) AS s
+	FROM t ORDER BY rn`)
+
+	query := p.statement().(*ast.SelectStatement).Query
+	if len(query.Columns) != 2 {
+		t.Fatalf("Expected 2 columns, got: %v", query.Columns)
+	}
+	over := query.Columns[0][3]
+	spec := over.Window
+	if spec == nil || spec.End != query.Columns[0][19].Pos {
+		t.Fatalf("Expected window specification of OVER, got: %v", over)
+	}
+	if len(spec.PartitionBy) != 2 || len(spec.OrderBy) != 1 ||
+		len(spec.OrderBy[0]) != 2 || len(spec.Frame) != 7 {
+		t.Errorf("Unexpected window specification: %v", spec)
+	}
+	for _, word := range query.Columns[1] {
+		if word.Window != nil {
+			t.Errorf("Expected no window specification in WITHIN GROUP")
+		}
+	}
+	if query.OrderBy == nil || len(query.OrderBy.Items) != 1 {
+		t.Errorf("Expected ORDER BY clause of the query, got: %v",
+			query.OrderBy)
+	}
+}
+
+// Test for parsing WINDOW clause of SELECT query.
+func TestParseWindowClause(t *testing.T) {
+	p := testParser(`SELECT SUM(v) OVER w, AVG(v) OVER (w2 ROWS UNBOUNDED PRECEDING)
+	FROM t window
+	WINDOW w AS (PARTITION BY a ORDER BY b RANGE CURRENT ROW), w2 AS (w)
+	ORDER BY a`)
+
+	query := p.statement().(*ast.SelectStatement).Query
+	if alias := query.From.TableOrViewName.Alias; alias == nil ||
+		*alias != "window" {
+		t.Errorf("Expected table alias window, got: %v", alias)
+	}
+	if len(query.Window) != 2 {
+		t.Fatalf("Expected 2 windows, got: %d", len(query.Window))
+	}
+	w, w2 := query.Window[0], query.Window[1]
+	if w.Name != "w" || len(w.Spec.PartitionBy) != 1 ||
+		len(w.Spec.OrderBy) != 1 || len(w.Spec.Frame) != 3 {
+		t.Errorf("Unexpected window w: %v", w.Spec)
+	}
+	if w2.Name != "w2" || w2.Spec.Name != "w" || w2.Spec.OrderBy != nil {
+		t.Errorf("Expected window w2 based on w, got: %v", w2.Spec)
+	}
+	if query.OrderBy == nil {
+		t.Errorf("Expected ORDER BY after WINDOW clause")
+	}
+}
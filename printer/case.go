@@ -0,0 +1,65 @@
+package printer
+
+import (
+	"mssfmt/ast"
+	"strings"
+)
+
+// Method caseExpr prints CASE expression in multiple lines. Each WHEN and
+// ELSE branch starts in a new line indented relative to CASE keyword and END
+// is aligned with CASE keyword. Results of branches are printed by exprBlock,
+// so nested CASE expressions are split as well.
+func (p *printer) caseExpr(caseExpr *ast.CaseExpr) {
+	column := p.width()
+	p.print(p.keyword("CASE"))
+	if caseExpr.Input != nil {
+		p.print(" ", p.expr(caseExpr.Input))
+	}
+
+	for _, when := range caseExpr.Whens {
+		p.alignedNewline(column + p.config.IndentWidth)
+		p.print(p.keyword("WHEN"), " ", p.expr(when.Condition), " ",
+			p.keyword("THEN"), " ")
+		p.exprBlock(when.Result)
+	}
+	if caseExpr.Else != nil {
+		p.alignedNewline(column + p.config.IndentWidth)
+		p.print(p.keyword("ELSE"), " ")
+		p.exprBlock(caseExpr.Else)
+	}
+	p.alignedNewline(column)
+	p.print(p.keyword("END"))
+}
+
+// Method alignedNewline starts a new line and pads it with spaces, so the
+// next string is printed at given column. Column is never less than current
+// indentation. Comments waiting for the new line are padded as well.
+func (p *printer) alignedNewline(column int) {
+	p.newline()
+	if padding := column - p.width(); padding > 0 {
+		p.nextLineComments(strings.Repeat(" ", padding))
+		p.print(strings.Repeat(" ", padding))
+	}
+}
+
+// Function anyLongCase checks if any of given expressions contains CASE
+// expression with more than one WHEN branch.
+func anyLongCase(exprs []ast.Expression) bool {
+	for _, expr := range exprs {
+		if hasLongCase(expr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Function hasLongCase checks if expression contains CASE expression with
+// more than one WHEN branch.
+func hasLongCase(expr ast.Expression) bool {
+	for _, word := range expr {
+		if word.Case != nil && len(word.Case.Whens) > 1 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,320 @@
+package printer
+
+import (
+	"bytes"
+	"mssfmt/ast"
+	"mssfmt/token"
+	"sort"
+	"strings"
+)
+
+// Type lineComment is a comment group collected for the end of the current
+// line. If it's printed in separate lines, they get indentation of the
+// following line, or given indentation when indent isn't negative.
+type lineComment struct {
+	group  *ast.CommentGroup
+	indent int
+}
+
+// Method leadingComments prints comments before given statement. Inner
+// comments of enclosing statements which precede the statement are printed
+// first, then leading comments of the statement, each in a separate line.
+func (p *printer) leadingComments(stmt ast.Statement) {
+	p.pendingBefore(stmt.Pos())
+	if p.lineStart || p.output.Len() == 0 {
+		comments := p.lineEnd
+		p.lineEnd = nil
+		for _, comment := range comments {
+			p.commentLines(comment.group.List)
+		}
+	}
+
+	for _, group := range p.comments.Filter(stmt, ast.LEADINGCOMMENT) {
+		p.commentLines(group.List)
+	}
+	p.stmtStart = p.output.Len()
+}
+
+// Method pendingBefore moves pending comments which precede given position to
+// the end of the current line.
+func (p *printer) pendingBefore(pos token.Position) {
+	for len(p.pending) > 0 && p.pending[0].Pos().Offset < pos.Offset {
+		p.toLineEnd(p.pending[0])
+		p.pending = p.pending[1:]
+	}
+}
+
+// Method commentsBetween checks if any pending comment is placed between
+// given positions.
+func (p *printer) commentsBetween(from, to token.Position) bool {
+	for _, group := range p.pending {
+		if offset := group.Pos().Offset; from.Offset < offset &&
+			offset < to.Offset {
+			return true
+		}
+	}
+	return false
+}
+
+// Method commentsAround checks if any comment in the line of the preceding
+// word waits for printing before the word at position first or directly
+// follows the word at position last. Comments at the end of the current line
+// precede any following word.
+func (p *printer) commentsAround(first, last token.Position) bool {
+	if len(p.lineEnd) > 0 {
+		return true
+	}
+	for _, group := range p.pending {
+		if offset := group.Pos().Offset; !group.Newline &&
+			(offset < first.Offset || offset == last.Offset+1) {
+			return true
+		}
+	}
+	return false
+}
+
+// Method commentLines prints given comments each in a separate line.
+func (p *printer) commentLines(comments []*ast.Comment) {
+	for _, comment := range comments {
+		p.print(strings.TrimRight(comment.Text, " \t"))
+		p.newline()
+	}
+}
+
+// Method innerComments adds inner comments of given node to pending comments.
+// Pending comment is moved to the end of the line when the word preceding it
+// is printed.
+func (p *printer) innerComments(node ast.Node) {
+	p.pending = append(p.pending, p.comments.Filter(node, ast.INNERCOMMENT)...)
+	sort.SliceStable(p.pending, func(i, j int) bool {
+		return p.pending[i].Pos().Offset < p.pending[j].Pos().Offset
+	})
+}
+
+// Method trailingComments moves trailing comments of given node and its inner
+// comments which are still pending to the end of the current line.
+func (p *printer) trailingComments(node ast.Node) {
+	inner := p.comments.Filter(node, ast.INNERCOMMENT)
+	pending := p.pending[:0]
+	for _, group := range p.pending {
+		if containsGroup(inner, group) {
+			p.toLineEnd(group)
+		} else {
+			pending = append(pending, group)
+		}
+	}
+	p.pending = pending
+
+	for _, group := range p.comments.Filter(node, ast.TRAILINGCOMMENT) {
+		p.toLineEnd(group)
+	}
+}
+
+// Function containsGroup checks if given comment group is in the list.
+func containsGroup(groups []*ast.CommentGroup, group *ast.CommentGroup) bool {
+	for _, g := range groups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
+// Method toLineEnd collects comment group for the end of the current line.
+func (p *printer) toLineEnd(group *ast.CommentGroup) {
+	p.lineEnd = append(p.lineEnd, lineComment{group: group, indent: -1})
+}
+
+// Method passed is called with position of each printed word. Spelling of
+// keywords moves past the word. Pending comments which follow the word are
+// moved to the end of the current line. Pending comments which precede the
+// word (because the word preceding them was printed as a part of some name or
+// keyword) are written after the previous line of the current statement.
+func (p *printer) passed(pos token.Position) {
+	if !pos.IsValid() {
+		return
+	}
+	for p.spelled < len(p.spelling) &&
+		p.spelling[p.spelled].Pos.Offset <= pos.Offset {
+		p.spelled++
+	}
+	if p.detached {
+		p.positions = append(p.positions, pos)
+		return
+	}
+	for len(p.pending) > 0 {
+		group := p.pending[0]
+		offset := group.Pos().Offset
+		if pos.Offset < offset-1 {
+			return
+		}
+		p.pending = p.pending[1:]
+		if pos.Offset < offset || !p.previousLine(group) {
+			p.toLineEnd(group)
+		}
+	}
+}
+
+// Method passedItem is called after printing list item which ends at given
+// position (together with the following comma if comma is true). Comments
+// directly following the item or the comma are moved to the end of the
+// current line.
+func (p *printer) passedItem(end token.Position, comma bool) {
+	p.passed(end)
+	if !end.IsValid() || p.detached {
+		return
+	}
+
+	offset := end.Offset + 1
+	for _, comment := range p.lineEnd {
+		if comment.group.Pos().Offset == offset {
+			offset += len(comment.group.List)
+		}
+	}
+	for len(p.pending) > 0 {
+		group := p.pending[0]
+		switch {
+		case group.Pos().Offset == offset:
+		case group.Pos().Offset == offset+1 && comma:
+			comma = false
+		default:
+			return
+		}
+		p.toLineEnd(group)
+		p.pending = p.pending[1:]
+		offset = group.Pos().Offset + len(group.List)
+	}
+}
+
+// Method detach runs given function which builds strings printed later, like
+// aligned rows of a list. Printed words don't move comments meanwhile, their
+// positions are returned instead and they should be replayed when the strings
+// are actually printed.
+func (p *printer) detach(build func()) []token.Position {
+	detached, positions, spelled := p.detached, p.positions, p.spelled
+	p.detached, p.positions = true, nil
+	build()
+	collected := p.positions
+	p.detached, p.positions = detached, append(positions, collected...)
+	p.spelled = spelled
+	return collected
+}
+
+// Method replay calls passed method with positions returned by detach.
+func (p *printer) replay(positions []token.Position) {
+	for _, pos := range positions {
+		p.passed(pos)
+	}
+}
+
+// Method previousLine writes comment group after the previous line if it's a
+// line of the current statement. Group is appended to the previous line if it
+// was written in the line of preceding word, otherwise its comments are
+// written in separate lines. Method returns false if there is no such line.
+func (p *printer) previousLine(group *ast.CommentGroup) bool {
+	out := p.output.Bytes()
+	end := bytes.LastIndexByte(out, '\n')
+	if end < 0 {
+		return false
+	}
+	start := bytes.LastIndexByte(out[:end], '\n') + 1
+	if start < p.stmtStart {
+		return false
+	}
+	line := out[start:end]
+
+	// Comments in separate lines precede the current line, so they get its
+	// indentation.
+	indent := strings.Repeat(p.indentation, p.indent)
+	if !p.lineStart {
+		current := out[end+1:]
+		indent = string(current[:len(current)-len(bytes.TrimLeft(current, " \t"))])
+	}
+
+	var text bytes.Buffer
+	if group.Newline || len(bytes.TrimSpace(line)) == 0 ||
+		bytes.Contains(line, []byte("--")) {
+		end++
+		for _, comment := range group.List {
+			text.WriteString(indent)
+			text.WriteString(strings.TrimRight(comment.Text, " \t"))
+			text.WriteByte('\n')
+		}
+	} else {
+		for _, comment := range group.List {
+			text.WriteString(" " + strings.TrimRight(comment.Text, " \t"))
+		}
+	}
+
+	tail := append([]byte(nil), out[end:]...)
+	p.output.Truncate(end)
+	p.output.Write(text.Bytes())
+	p.output.Write(tail)
+	return true
+}
+
+// Method flushComments writes comments collected for the end of the current
+// line. It's called by newline method before the line break. Comments which
+// were written in separate lines in the source or which cannot follow the
+// current line (because it's empty or it already contains line comment) are
+// returned and printed before the next line.
+func (p *printer) flushComments() []lineComment {
+	if len(p.lineEnd) == 0 {
+		return nil
+	}
+	comments := p.lineEnd
+	p.lineEnd = nil
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].group.Pos().Offset < comments[j].group.Pos().Offset
+	})
+
+	out := p.output.Bytes()
+	line := out[bytes.LastIndexByte(out, '\n')+1:]
+	empty := p.lineStart || len(line) == 0
+	hasLineComment := bytes.Contains(line, []byte("--"))
+
+	next := make([]lineComment, 0, 2)
+	for _, comment := range comments {
+		if comment.group.Newline || empty || hasLineComment {
+			next = append(next, comment)
+			continue
+		}
+		for _, c := range comment.group.List {
+			p.output.WriteString(" " + strings.TrimRight(c.Text, " \t"))
+			hasLineComment = hasLineComment || strings.HasPrefix(c.Text, "--")
+		}
+	}
+	return next
+}
+
+// Method keepIndent makes comments collected for the end of the current line
+// keep the current indentation when they are printed in separate lines. It's
+// used after the last item of a list, so its comments stay inside the list.
+func (p *printer) keepIndent() {
+	for id := range p.lineEnd {
+		p.lineEnd[id].indent = p.indent
+	}
+}
+
+// Method nextLineComments prints comments returned by flushComments each in
+// a separate line. They are printed just before the next line with its
+// indentation and padding, so they precede the same line when the script is
+// formatted again.
+func (p *printer) nextLineComments(padding string) {
+	if len(p.nextLine) == 0 {
+		return
+	}
+	comments, lineEnd, indent := p.nextLine, p.lineEnd, p.indent
+	p.nextLine, p.lineEnd = nil, nil
+	for _, comment := range comments {
+		p.indent = indent
+		if comment.indent >= 0 {
+			p.indent = comment.indent
+		}
+		for _, c := range comment.group.List {
+			p.print(padding, strings.TrimRight(c.Text, " \t"))
+			p.newline()
+		}
+	}
+	p.lineEnd, p.indent = lineEnd, indent
+}
@@ -0,0 +1,210 @@
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mssfmt/ast"
+	"strings"
+)
+
+// Config controls style of printed scripts. IndentWidth is the number of
+// spaces of a single level of indentation (or width of a tab when UseTabs is
+// true) and LineWidth is the maximum width of a line. Lists which don't fit
+// into the line are printed one item per line and long expressions are
+// wrapped at operators and arguments. KeywordCase, FunctionCase and
+// TypeCase are cases of keywords, names of built-in functions and names of
+// built-in data types. Case of identifiers, strings and delimited names is
+// never changed. LeadingCommas places commas of lists printed in separate
+// lines at the beginning of lines and CollapseLists prints lists in a single
+// line when they fit into it. Aliases, assignments and declarations printed
+// in consecutive lines are aligned in columns, except cells wider than the
+// narrowest cell of their column by more than AlignThreshold. Zero
+// AlignThreshold disables alignment. AliasKeyword, OuterKeyword and
+// InnerKeyword say if optional AS of table and column aliases, OUTER of outer
+// joins and INNER of inner joins are printed always, never or as they are
+// written in the source. Semicolons terminates statements recognized by the
+// parser except blocks and control-of-flow statements (see Terminable).
+type Config struct {
+	IndentWidth    int      `json:"indentWidth"`
+	UseTabs        bool     `json:"useTabs"`
+	LineWidth      int      `json:"lineWidth"`
+	KeywordCase    Case     `json:"keywordCase"`
+	FunctionCase   Case     `json:"functionCase"`
+	TypeCase       Case     `json:"typeCase"`
+	LeadingCommas  bool     `json:"leadingCommas"`
+	CollapseLists  bool     `json:"collapseLists"`
+	AlignThreshold int      `json:"alignThreshold"`
+	AliasKeyword   Optional `json:"aliasKeyword"`
+	OuterKeyword   Optional `json:"outerKeyword"`
+	InnerKeyword   Optional `json:"innerKeyword"`
+	Semicolons     bool     `json:"semicolons"`
+}
+
+// DefaultConfig is the style used by Fprint. Scripts formatted by mssfmt look
+// the same unless the style is explicitly changed.
+var DefaultConfig = Config{
+	IndentWidth:    4,
+	UseTabs:        false,
+	LineWidth:      80,
+	KeywordCase:    UPPER,
+	FunctionCase:   UPPER,
+	TypeCase:       PRESERVE,
+	LeadingCommas:  false,
+	CollapseLists:  false,
+	AlignThreshold: 20,
+	AliasKeyword:   KEEP,
+	OuterKeyword:   KEEP,
+	InnerKeyword:   KEEP,
+	Semicolons:     false,
+}
+
+// Case is an enum for cases of printed words. Words are printed in upper
+// case, lower case or as they are written in the source.
+type Case int
+
+const (
+	UPPER Case = iota
+	LOWER
+	PRESERVE
+)
+
+var cases = [...]string{
+	UPPER:    "upper",
+	LOWER:    "lower",
+	PRESERVE: "preserve",
+}
+
+// Function ParseCase returns Case of given name, like "upper" or "lower".
+func ParseCase(name string) (Case, error) {
+	for c, caseName := range cases {
+		if strings.EqualFold(name, caseName) {
+			return Case(c), nil
+		}
+	}
+	return UPPER, fmt.Errorf("unknown case %q, expected upper, lower or "+
+		"preserve", name)
+}
+
+// String returns name of the case as it's used in configuration files.
+func (c Case) String() string {
+	if 0 <= c && int(c) < len(cases) {
+		return cases[c]
+	}
+	return fmt.Sprintf("Case(%d)", int(c))
+}
+
+// Set parses the case from its name, so Case can be used as flag.Value.
+func (c *Case) Set(name string) error {
+	parsed, err := ParseCase(name)
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+// MarshalJSON encodes the case as its name.
+func (c Case) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+// UnmarshalJSON decodes the case from its name.
+func (c *Case) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	return c.Set(name)
+}
+
+// Optional is an enum for printing of optional keywords. Keywords are kept as
+// they are written in the source, always printed or never printed.
+type Optional int
+
+const (
+	KEEP Optional = iota
+	ALWAYS
+	NEVER
+)
+
+var optionals = [...]string{
+	KEEP:   "keep",
+	ALWAYS: "always",
+	NEVER:  "never",
+}
+
+// Function ParseOptional returns Optional of given name, like "keep" or
+// "never".
+func ParseOptional(name string) (Optional, error) {
+	for o, optionalName := range optionals {
+		if strings.EqualFold(name, optionalName) {
+			return Optional(o), nil
+		}
+	}
+	return KEEP, fmt.Errorf("unknown option %q, expected keep, always or "+
+		"never", name)
+}
+
+// String returns name of the option as it's used in configuration files.
+func (o Optional) String() string {
+	if 0 <= o && int(o) < len(optionals) {
+		return optionals[o]
+	}
+	return fmt.Sprintf("Optional(%d)", int(o))
+}
+
+// Set parses the option from its name, so Optional can be used as
+// flag.Value.
+func (o *Optional) Set(name string) error {
+	parsed, err := ParseOptional(name)
+	if err != nil {
+		return err
+	}
+	*o = parsed
+	return nil
+}
+
+// MarshalJSON encodes the option as its name.
+func (o Optional) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.String())
+}
+
+// UnmarshalJSON decodes the option from its name.
+func (o *Optional) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	return o.Set(name)
+}
+
+// Function optional checks if optional keyword is printed according to the
+// option. Argument written says if the keyword is written in the source.
+func optional(written bool, option Optional) bool {
+	return option == ALWAYS || written && option == KEEP
+}
+
+// Fprint formats given T-SQL script in the style described by cfg and writes
+// the result to w. Comments of the script are reattached to printed nodes.
+func (cfg *Config) Fprint(w io.Writer, script *ast.Script) error {
+	p := printer{config: *cfg, comments: script.Comments,
+		spelling: script.Spelling, stmtEnd: -1,
+		aliases: make(map[int]bool, len(script.Aliases))}
+	for _, alias := range script.Aliases {
+		p.aliases[alias.Offset] = true
+	}
+	if p.config.UseTabs {
+		p.indentation = "\t"
+	} else {
+		p.indentation = strings.Repeat(" ", p.config.IndentWidth)
+	}
+	p.script(script)
+	if len(p.lineEnd) > 0 {
+		p.newline()
+	}
+	p.nextLineComments("")
+
+	_, err := w.Write(p.output.Bytes())
+	return err
+}
@@ -0,0 +1,49 @@
+package printer
+
+import "mssfmt/ast"
+
+// Method ifStatement prints IF ... ELSE statement. BEGIN ... END blocks start
+// in a new line at the same level as IF, other statements are indented.
+// ELSE IF is printed in a single line unless there are comments between ELSE
+// and IF.
+func (p *printer) ifStatement(stmt *ast.IfStatement) {
+	p.print(p.keyword("IF"), " ")
+	p.exprBlock(stmt.Condition)
+	p.body(stmt.Then)
+
+	if stmt.Else == nil {
+		return
+	}
+	p.newline()
+	p.print(p.keyword("ELSE"))
+	elseIf, isIf := stmt.Else.(*ast.IfStatement)
+	if isIf && len(p.comments.Filter(elseIf, ast.LEADINGCOMMENT)) == 0 {
+		p.print(" ")
+		p.statement(elseIf)
+		return
+	}
+	p.body(stmt.Else)
+}
+
+// Method whileStatement prints WHILE loop with its body.
+func (p *printer) whileStatement(stmt *ast.WhileStatement) {
+	p.print(p.keyword("WHILE"), " ")
+	p.exprBlock(stmt.Condition)
+	p.body(stmt.Body)
+}
+
+// Method body prints body of IF, ELSE or WHILE in a new line. Single
+// statements are indented, BEGIN ... END blocks aren't.
+func (p *printer) body(stmt ast.Statement) {
+	p.pendingBefore(stmt.Pos())
+	if _, isBlock := stmt.(*ast.BlockStatement); isBlock {
+		p.newline()
+		p.statement(stmt)
+		return
+	}
+
+	p.indent++
+	p.newline()
+	p.statement(stmt)
+	p.indent--
+}
@@ -0,0 +1,71 @@
+package printer
+
+import (
+	"mssfmt/ast"
+	"strings"
+)
+
+// Method declareCursor prints declaration of a named cursor. Cursor query
+// starts in a new line after FOR keyword with one level of indentation.
+func (p *printer) declareCursor(stmt *ast.DeclareCursorStatement) {
+	p.print(p.keyword("DECLARE"), " ", stmt.Name)
+	for _, option := range stmt.ISOOptions {
+		p.print(" ", p.keyword(option))
+	}
+	p.print(" ", p.keyword("CURSOR"))
+	for _, option := range stmt.Options {
+		p.print(" ", p.keyword(option))
+	}
+	p.print(" ", p.keyword("FOR"))
+
+	if stmt.Query != nil {
+		p.indent++
+		p.newline()
+		p.selectStatement(stmt.Query)
+		p.indent--
+	}
+	if len(stmt.For) > 0 {
+		p.newline()
+		p.print(p.keyword("FOR"), " ", p.optionExpr(stmt.For))
+	}
+}
+
+// Method cursorStatement prints OPEN, CLOSE or DEALLOCATE statement.
+func (p *printer) cursorStatement(stmt *ast.CursorStatement) {
+	switch stmt.Kind {
+	case ast.OPENCURSOR:
+		p.print(p.keyword("OPEN"))
+	case ast.CLOSECURSOR:
+		p.print(p.keyword("CLOSE"))
+	case ast.DEALLOCATECURSOR:
+		p.print(p.keyword("DEALLOCATE"))
+	}
+	p.print(" ", p.cursorName(stmt.Global, stmt.Cursor))
+}
+
+// Method cursorName returns name of a cursor with optional GLOBAL keyword.
+func (p *printer) cursorName(global bool, name string) string {
+	if global {
+		return p.keyword("GLOBAL") + " " + name
+	}
+	return name
+}
+
+// Method fetchStatement prints FETCH statement in a single line. Orientation
+// keyword is uppercased.
+func (p *printer) fetchStatement(stmt *ast.FetchStatement) {
+	p.print(p.keyword("FETCH"))
+	if len(stmt.Orientation) > 0 {
+		p.print(" ", p.keyword(stmt.Orientation[0].Literal))
+		if len(stmt.Orientation) > 1 {
+			p.print(" ", p.expr(stmt.Orientation[1:]))
+		}
+	}
+	if stmt.From {
+		p.print(" ", p.keyword("FROM"))
+	}
+	p.print(" ", p.cursorName(stmt.Global, stmt.Cursor))
+	if len(stmt.Into) > 0 {
+		p.print(" ", p.keyword("INTO"), " ", strings.Join(stmt.Into, ", "))
+	}
+}
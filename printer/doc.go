@@ -0,0 +1,287 @@
+package printer
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+	"unicode/utf8"
+)
+
+// Type doc is a document of Wadler's pretty printer. Document is a text, a
+// line break, a nested document, a group or a concatenation of documents.
+// Line breaks of a group are printed as spaces (or nothing for soft breaks)
+// when the whole group fits into the line, otherwise they start new lines.
+// Hard line breaks always start new lines, so their groups never fit.
+// Nested documents get one more level of indentation after line breaks.
+// Positions of words of the text are replayed after printing it.
+type doc struct {
+	kind      docKind
+	text      string
+	positions []token.Position
+	parts     []*doc
+}
+
+// Type docKind is an enum for kinds of documents.
+type docKind int
+
+const (
+	TEXTDOC docKind = iota
+	LINEDOC
+	SOFTLINEDOC
+	HARDLINEDOC
+	NESTDOC
+	GROUPDOC
+	CONCATDOC
+)
+
+// Function text returns document of the text.
+func text(str string, positions ...token.Position) *doc {
+	return &doc{kind: TEXTDOC, text: str, positions: positions}
+}
+
+// Function line returns line break which is a space in a flat group.
+func line() *doc {
+	return &doc{kind: LINEDOC}
+}
+
+// Function softline returns line break which is nothing in a flat group.
+func softline() *doc {
+	return &doc{kind: SOFTLINEDOC}
+}
+
+// Function hardline returns line break which is never flat. It's used after
+// words followed by comments, so the comments end their lines.
+func hardline() *doc {
+	return &doc{kind: HARDLINEDOC}
+}
+
+// Function nest returns documents with one more level of indentation.
+func nest(parts ...*doc) *doc {
+	return &doc{kind: NESTDOC, parts: parts}
+}
+
+// Function group returns documents which are printed in a single line if they
+// fit into it.
+func group(parts ...*doc) *doc {
+	return &doc{kind: GROUPDOC, parts: parts}
+}
+
+// Function concat returns concatenation of documents.
+func concat(parts ...*doc) *doc {
+	return &doc{kind: CONCATDOC, parts: parts}
+}
+
+// Type docCommand is a document waiting for printing with its level of
+// indentation (relative to the indentation of the first line) and mode.
+type docCommand struct {
+	indent int
+	flat   bool
+	doc    *doc
+}
+
+// Method render prints the document. Groups are printed flat if they fit into
+// the rest of the line, otherwise their line breaks start new lines. This is
+// the algorithm described by Wadler in "A prettier printer" working with
+// a stack of commands.
+func (p *printer) render(root *doc) {
+	indent := p.indent
+	commands := []docCommand{{doc: root}}
+	for len(commands) > 0 {
+		cmd := commands[len(commands)-1]
+		commands = commands[:len(commands)-1]
+
+		switch cmd.doc.kind {
+		case TEXTDOC:
+			p.print(cmd.doc.text)
+			p.replay(cmd.doc.positions)
+		case LINEDOC, SOFTLINEDOC, HARDLINEDOC:
+			if !cmd.flat || cmd.doc.kind == HARDLINEDOC {
+				p.indent = indent + cmd.indent
+				p.newline()
+			} else if cmd.doc.kind == LINEDOC {
+				p.print(" ")
+			}
+		case NESTDOC, GROUPDOC, CONCATDOC:
+			next := cmd
+			if cmd.doc.kind == NESTDOC {
+				next.indent++
+			}
+			if cmd.doc.kind == GROUPDOC && !cmd.flat {
+				next.flat = true
+				next.flat = p.fitsCommands(append(commands, next))
+			}
+			for id := len(cmd.doc.parts) - 1; id >= 0; id-- {
+				next.doc = cmd.doc.parts[id]
+				commands = append(commands, next)
+			}
+		}
+	}
+	p.indent = indent
+}
+
+// Method fitsCommands checks if the last command fits into the rest of the
+// line together with the following commands up to the first line break.
+func (p *printer) fitsCommands(commands []docCommand) bool {
+	width := p.config.LineWidth - p.width()
+	stack := []docCommand{commands[len(commands)-1]}
+	rest := len(commands) - 1
+	for width >= 0 {
+		if len(stack) == 0 {
+			if rest == 0 {
+				return true
+			}
+			rest--
+			stack = append(stack, commands[rest])
+		}
+		cmd := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		switch cmd.doc.kind {
+		case TEXTDOC:
+			width -= utf8.RuneCountInString(cmd.doc.text)
+		case LINEDOC, SOFTLINEDOC, HARDLINEDOC:
+			if !cmd.flat {
+				return true
+			}
+			if cmd.doc.kind == HARDLINEDOC {
+				return false
+			}
+			if cmd.doc.kind == LINEDOC {
+				width--
+			}
+		default:
+			for id := len(cmd.doc.parts) - 1; id >= 0; id-- {
+				next := cmd
+				next.doc = cmd.doc.parts[id]
+				stack = append(stack, next)
+			}
+		}
+	}
+	return false
+}
+
+// Binary operators at which expressions are broken, from the lowest
+// precedence.
+var breakOperators = [][]token.Token{
+	{token.OR},
+	{token.AND},
+	{token.ADD, token.SUB},
+	{token.MUL, token.DIV, token.MOD},
+}
+
+// Separator of arguments in parentheses.
+var commaOperator = []token.Token{token.COMMA}
+
+// Method exprDoc returns document of the expression. Expression is broken
+// before binary operators of the lowest precedence found outside of
+// parentheses. The following operands are nested when hang is true, so they
+// are indented relative to the line in which the expression starts. Contents
+// of parentheses are groups broken after opening parenthesis and after commas
+// separating arguments, each argument starts a line. Contents of parentheses
+// with comments following their words are always broken there, so comments
+// stay after the words they follow. Subqueries in parentheses aren't broken.
+func (p *printer) exprDoc(expr ast.Expression, hang bool) *doc {
+	for _, operators := range breakOperators {
+		if splits := splitOperators(expr, operators); len(splits) > 0 {
+			parts := []*doc{p.exprDoc(expr[:splits[0]], hang)}
+			for id, split := range splits {
+				end := len(expr)
+				if id+1 < len(splits) {
+					end = splits[id+1]
+				}
+				parts = append(parts, line(), p.wordDoc(expr, split), text(" "),
+					p.exprDoc(expr[split+1:end], true))
+			}
+			if hang {
+				return group(parts[0], nest(parts[1:]...))
+			}
+			return group(parts...)
+		}
+	}
+
+	parts := make([]*doc, 0, len(expr))
+	for id := 0; id < len(expr); id++ {
+		if id > 0 && needsSpace(expr, id) {
+			parts = append(parts, text(" "))
+		}
+		end := expr.ClosingParen(id)
+		if expr[id].Token != token.LPAREN || end == len(expr) || end == id+1 {
+			parts = append(parts, p.wordDoc(expr, id))
+			continue
+		}
+		if expr[id+1].Token == token.SELECT {
+			parts = append(parts, p.wordDoc(expr, id))
+			for id++; id <= end; id++ {
+				if needsSpace(expr, id) {
+					parts = append(parts, text(" "))
+				}
+				parts = append(parts, p.wordDoc(expr, id))
+			}
+			id = end
+			continue
+		}
+
+		args := []*doc{p.lineAfter(expr, id, softline())}
+		start := id + 1
+		for _, comma := range splitOperators(expr[id+1:end], commaOperator) {
+			comma += id + 1
+			args = append(args, p.exprDoc(expr[start:comma], false),
+				p.wordDoc(expr, comma), p.lineAfter(expr, comma, line()))
+			start = comma + 1
+		}
+		args = append(args, p.exprDoc(expr[start:end], false))
+		parts = append(parts, group(p.wordDoc(expr, id), nest(args...),
+			p.lineAfter(expr, end-1, softline()), p.wordDoc(expr, end)))
+		id = end
+	}
+	return concat(parts...)
+}
+
+// Method lineAfter returns hard line break when a comment follows expr[id]
+// word, otherwise it returns given line break.
+func (p *printer) lineAfter(expr ast.Expression, id int, br *doc) *doc {
+	if id+1 < len(expr) && p.commentsBetween(expr[id].Pos, expr[id+1].Pos) {
+		return hardline()
+	}
+	return br
+}
+
+// Method wordDoc returns document of expr[id] word.
+func (p *printer) wordDoc(expr ast.Expression, id int) *doc {
+	var str string
+	positions := p.detach(func() { str = p.exprWord(expr, id) })
+	return text(str, positions...)
+}
+
+// Function splitOperators returns indexes of binary operators of given kinds
+// which aren't placed in parentheses, CASE expressions or between BETWEEN
+// and its AND.
+func splitOperators(expr ast.Expression, operators []token.Token) []int {
+	splits := make([]int, 0, 4)
+	depth, between := 0, false
+	for id, word := range expr {
+		switch word.Token {
+		case token.LPAREN, token.CASE:
+			depth++
+		case token.RPAREN, token.END:
+			depth--
+		}
+		if depth != 0 || id == 0 {
+			continue
+		}
+		switch {
+		case word.Token == token.BETWEEN:
+			between = true
+		case word.Token == token.AND && between:
+			between = false
+		case (word.Token == token.ADD || word.Token == token.SUB) &&
+			isUnary(expr, id):
+		default:
+			for _, operator := range operators {
+				if word.Token == operator {
+					splits = append(splits, id)
+				}
+			}
+		}
+	}
+	return splits
+}
@@ -0,0 +1,12 @@
+package printer
+
+import "mssfmt/ast"
+
+// Method dropStatement prints DROP statement in a single line.
+func (p *printer) dropStatement(drop *ast.DropStatement) {
+	p.print(p.keyword("DROP"), " ", p.keyword(drop.Object))
+	if drop.IfExists {
+		p.print(" ", p.keyword("IF EXISTS"))
+	}
+	p.print(" ", p.exprList(drop.Names))
+}
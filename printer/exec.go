@@ -0,0 +1,100 @@
+package printer
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+	"strings"
+	"unicode/utf8"
+)
+
+// Method execStatement prints EXEC statement. Arguments are printed in the
+// same line as procedure name if the whole statement fits into the line and
+// there are no comments between arguments. Otherwise each argument is printed in a separate line with one level of
+// indentation and names of arguments are aligned.
+func (p *printer) execStatement(stmt *ast.ExecStatement) {
+	head := p.keyword(stmt.Keyword)
+	if stmt.ReturnStatus != "" {
+		head += " " + stmt.ReturnStatus + " ="
+	}
+	if stmt.Name != "" {
+		head += " " + stmt.Name
+	}
+
+	rows := make([][]string, len(stmt.Args))
+	args := make([]string, len(stmt.Args))
+	positions := make([][]token.Position, len(stmt.Args))
+	for id, arg := range stmt.Args {
+		positions[id] = append(p.detach(func() {
+			rows[id] = p.execArgument(arg)
+		}), arg.End)
+		args[id] = strings.Join(rows[id], " ")
+	}
+	tail := ""
+	tailPositions := p.detach(func() { tail = p.execTail(stmt) })
+
+	line := head + tail
+	if len(args) > 0 {
+		line = head + " " + strings.Join(args, ", ") + tail
+	}
+	if stmt.Dynamic {
+		line = head + " (" + strings.Join(args, ", ") + ")" + tail
+	}
+	commented := len(args) > 1 && p.commentsBetween(positions[0][0],
+		stmt.Args[len(args)-1].End)
+	if len(args) < 2 || !commented &&
+		p.width()+utf8.RuneCountInString(line) <= p.config.LineWidth {
+		p.print(line)
+		for _, words := range positions {
+			p.replay(words)
+		}
+		p.replay(tailPositions)
+		return
+	}
+
+	p.print(head)
+	if stmt.Dynamic {
+		p.print(" (")
+	}
+	p.indent++
+	p.listLines(alignColumns(rows, p.config.AlignThreshold), positions)
+	p.indent--
+	if stmt.Dynamic {
+		p.newline()
+		p.print(")")
+	}
+	if tail != "" {
+		p.newline()
+		p.print(strings.TrimSpace(tail))
+		p.replay(tailPositions)
+	}
+}
+
+// Method execArgument returns cells of EXEC argument - name and the value
+// with "=" sign and OUTPUT keyword. Positional argument consists of a single
+// cell.
+func (p *printer) execArgument(arg *ast.ExecArgument) []string {
+	value := p.expr(arg.Value)
+	if arg.Output != "" {
+		value += " " + p.keyword(arg.Output)
+	}
+	if arg.Name == "" {
+		return []string{value}
+	}
+	return []string{arg.Name, "= " + value}
+}
+
+// Method execTail returns WITH, AS and AT clauses of EXEC statement with a
+// leading space.
+func (p *printer) execTail(stmt *ast.ExecStatement) string {
+	tail := ""
+	if len(stmt.Options) > 0 {
+		tail += " " + p.keyword("WITH") + " " + p.optionList(stmt.Options)
+	}
+	if len(stmt.Context) > 0 {
+		tail += " " + p.expr(stmt.Context)
+	}
+	if len(stmt.At) > 0 {
+		tail += " " + p.keyword("AT") + " " + p.expr(stmt.At)
+	}
+	return tail
+}
@@ -0,0 +1,271 @@
+package printer
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+	"strings"
+	"unicode/utf8"
+)
+
+// Method expr returns flat T-SQL expression as a single line string. Words
+// are separated by a single space except places where space isn't needed,
+// like before comma or between function name and opening parenthesis.
+func (p *printer) expr(expr ast.Expression) string {
+	var line strings.Builder
+
+	for id := range expr {
+		if id > 0 && needsSpace(expr, id) {
+			line.WriteByte(' ')
+		}
+		line.WriteString(p.exprWord(expr, id))
+	}
+	return line.String()
+}
+
+// Method exprWord returns single word of the expression as a string. Names of
+// built-in functions and data types are printed in their cases, other words
+// are printed by word method. Words after a period are names of columns or
+// functions of a schema, so they are printed as they are. So are keywords
+// used as names (see IsKeywordAt).
+func (p *printer) exprWord(expr ast.Expression, id int) string {
+	switch {
+	case id > 0 && expr[id-1].Token == token.PERIOD:
+		p.passed(expr[id].Pos)
+		return expr[id].Literal
+	case isFunctionName(expr, id):
+		p.passed(expr[id].Pos)
+		return p.wordCase(expr[id], p.config.FunctionCase)
+	case isTypeName(expr, id):
+		p.passed(expr[id].Pos)
+		return p.wordCase(expr[id], p.config.TypeCase)
+	case isFrameWord(expr, id) || isWithinGroup(expr, id):
+		p.passed(expr[id].Pos)
+		return p.keyword(expr[id].Literal)
+	case expr[id].Token.IsKeyword() && !IsKeywordAt(expr, id):
+		p.passed(expr[id].Pos)
+		return expr[id].Literal
+	}
+	return p.word(expr[id])
+}
+
+// Method exprBlock prints expression which may span multiple lines. CASE
+// expressions with multiple branches are printed with each WHEN in a
+// separate line. When the expression doesn't fit into the line, all CASE
+// expressions are split and content of OVER clauses is printed in separate
+// lines. Expressions with comments are printed by the document printer too,
+// so lists with comments are broken. Otherwise the expression is printed in
+// the current line.
+func (p *printer) exprBlock(expr ast.Expression) {
+	var line string
+	positions := p.detach(func() { line = p.expr(expr) })
+	long := p.width()+utf8.RuneCountInString(line) > p.config.LineWidth
+	commented := len(expr) > 0 &&
+		p.commentsBetween(expr[0].Pos, expr[len(expr)-1].Pos)
+	if !long && !hasLongCase(expr) && !commented {
+		p.print(line)
+		p.replay(positions)
+		return
+	}
+
+	start := 0
+	flush := func(end int) {
+		if start < end {
+			if start > 0 && needsSpace(expr, start) {
+				p.print(" ")
+			}
+			p.render(p.exprDoc(expr[start:end], true))
+		}
+	}
+	for id := 0; id < len(expr); id++ {
+		var end int
+		switch {
+		case expr[id].Window != nil && long:
+			end = endOf(expr, id, expr[id].Window.End)
+			if end == 0 {
+				continue
+			}
+			flush(id)
+			if id > 0 && needsSpace(expr, id) {
+				p.print(" ")
+			}
+			p.overClause(expr[id:end])
+		case expr[id].Case != nil:
+			caseExpr := expr[id].Case
+			end = endOf(expr, id, caseExpr.End)
+			if end == 0 || !long && len(caseExpr.Whens) < 2 {
+				continue
+			}
+			flush(id)
+			if id > 0 && needsSpace(expr, id) {
+				p.print(" ")
+			}
+			p.caseExpr(caseExpr)
+		default:
+			continue
+		}
+		start, id = end, end-1
+	}
+	flush(len(expr))
+}
+
+// Function endOf returns index of the word following the word at position end,
+// searching from expr[id]. It's used for ends of CASE expressions and OVER
+// clauses. It returns 0 when there's no such word.
+func endOf(expr ast.Expression, id int, end token.Position) int {
+	for ; id < len(expr); id++ {
+		if expr[id].Pos == end {
+			return id + 1
+		}
+	}
+	return 0
+}
+
+// Method valuesBlock prints expression which may contain table value
+// constructor. Constructor with multiple rows is printed as a list with each
+// row in a separate line with one level of indentation, unless lists are
+// collapsed and it fits into the line. Words after the last row are printed
+// in a new line.
+func (p *printer) valuesBlock(expr ast.Expression) {
+	start, rows := valuesRows(expr)
+	if len(rows) < 2 {
+		p.print(p.expr(expr))
+		return
+	}
+
+	items := make([]string, len(rows))
+	positions := make([][]token.Position, len(rows))
+	for id, row := range rows {
+		positions[id] = append(p.detach(func() {
+			items[id] = p.expr(row)
+		}), exprEnd(row))
+	}
+	end := start
+	for _, row := range rows {
+		end += len(row) + 1
+	}
+	end--
+
+	p.print(p.expr(expr[:start]))
+	if p.config.CollapseLists && p.inline(items, positions, " ", "") {
+		if end < len(expr) && needsSpace(expr, end) {
+			p.print(" ")
+		}
+	} else {
+		p.indent++
+		p.listLines(items, positions)
+		p.indent--
+		if end < len(expr) {
+			p.newline()
+		}
+	}
+	if end < len(expr) {
+		p.valuesBlock(expr[end:])
+	}
+}
+
+// Function valuesRows finds the first table value constructor with rows in
+// parentheses. It returns index of the first row and words of rows.
+func valuesRows(expr ast.Expression) (int, []ast.Expression) {
+	for id := 0; id+1 < len(expr); id++ {
+		if expr[id].Token != token.VALUES || expr[id+1].Token != token.LPAREN {
+			continue
+		}
+		rows := make([]ast.Expression, 0, 4)
+		for open := id + 1; ; open += 2 {
+			end := expr.ClosingParen(open)
+			if end == len(expr) {
+				return 0, nil
+			}
+			rows = append(rows, expr[open:end+1])
+			open = end
+			if end+2 >= len(expr) || expr[end+1].Token != token.COMMA ||
+				expr[end+2].Token != token.LPAREN {
+				return id + 1, rows
+			}
+		}
+	}
+	return 0, nil
+}
+
+// Method exprList returns given expressions as a single line string. Each
+// expression is printed by expr method and they are separated by comma.
+func (p *printer) exprList(exprs []ast.Expression) string {
+	strs := make([]string, len(exprs))
+	for id, expr := range exprs {
+		strs[id] = p.expr(expr)
+	}
+	return strings.Join(strs, ", ")
+}
+
+// Method word returns single Word as a string. Keywords are printed in
+// KeywordCase, other words are printed as they are.
+func (p *printer) word(word ast.Word) string {
+	p.passed(word.Pos)
+	if word.Token.IsKeyword() {
+		return p.wordCase(word, p.config.KeywordCase)
+	}
+	return word.Literal
+}
+
+// Function needsSpace checks if space is needed between expr[id-1] and
+// expr[id] words. Words which touched in the source aren't separated when
+// a space could change how they are scanned, like in "@a+=1" or "a|b".
+func needsSpace(expr ast.Expression, id int) bool {
+	prev, curr := expr[id-1].Token, expr[id].Token
+
+	switch {
+	case expr[id-1].Touches(expr[id]) && token.Inseparable(prev, curr):
+		return false
+	case prev == token.SUB && curr == token.SUB:
+		return true
+	case prev == token.LPAREN || prev == token.PERIOD || prev == token.COLON:
+		return false
+	case curr == token.RPAREN || curr == token.COMMA ||
+		curr == token.PERIOD || curr == token.SEMICOLON || curr == token.COLON:
+		return false
+	case curr == token.LPAREN:
+		return prev != token.IDENT && !isFunction(prev) ||
+			isWithinGroup(expr, id-1) || isResultSets(expr, id-1) ||
+			isInsertTarget(expr, id-1)
+	case prev == token.SUB || prev == token.ADD:
+		return !isUnary(expr, id-1)
+	}
+	return true
+}
+
+// Function isInsertTarget checks if expr[id] is the last word of a name of
+// table following INSERT or INTO, so parenthesis after it starts a list of
+// columns rather than arguments of a function.
+func isInsertTarget(expr ast.Expression, id int) bool {
+	for ; id > 0; id-- {
+		if expr[id].Token != token.IDENT && expr[id].Token != token.PERIOD {
+			break
+		}
+	}
+	return expr[id].Token == token.INTO || expr[id].Token == token.INSERT
+}
+
+// Function isUnary checks if operator expr[id] is unary plus or minus.
+func isUnary(expr ast.Expression, id int) bool {
+	if id == 0 {
+		return true
+	}
+	prev := expr[id-1].Token
+	return prev == token.LPAREN || prev == token.COMMA ||
+		(prev.IsOperator() && prev != token.RPAREN) ||
+		(IsKeywordAt(expr, id-1) && prev != token.END && prev != token.NULL)
+}
+
+// Function isFunction checks if given keyword token is also a name of T-SQL
+// function, like SUM or LEFT.
+func isFunction(tok token.Token) bool {
+	switch tok {
+	case token.APPROX_COUNT_DISTINCT, token.AVG, token.CHECKSUM_AGG,
+		token.COUNT, token.COUNT_BIG, token.GROUPING, token.GROUPING_ID,
+		token.MAX, token.MIN, token.STDEV, token.STDEVP, token.STRING_AGG,
+		token.SUM, token.VAR, token.VARP, token.LEFT, token.RIGHT,
+		token.CUBE, token.ROLLUP, token.INDEX, token.IDENTITY:
+		return true
+	}
+	return false
+}
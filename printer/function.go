@@ -0,0 +1,60 @@
+package printer
+
+import "mssfmt/ast"
+
+// Method function prints CREATE/ALTER FUNCTION statement. Parameters are
+// printed in the same way as procedure parameters but always in parentheses.
+func (p *printer) function(function *ast.FunctionStatement) {
+	p.createMode(function.Mode)
+	p.print(" ", p.keyword("FUNCTION"), " ", function.Name)
+
+	if len(function.Parameters) == 0 {
+		p.print("()")
+	} else {
+		p.parameters(function.Parameters, "(", ")")
+	}
+
+	p.newline()
+	p.print(p.keyword("RETURNS"), " ")
+	switch function.Kind {
+	case ast.SCALAR:
+		p.print(p.dataType(function.ReturnType))
+	case ast.INLINETABLE:
+		p.print(p.keyword("TABLE"))
+	case ast.MULTISTATEMENTTABLE:
+		p.print(function.ReturnVariable, " ", p.keyword("TABLE"))
+		p.tableDefinition(function.ReturnTable)
+	}
+
+	p.options(function.Options)
+	if function.ASKeyword {
+		p.newline()
+		p.print(p.keyword("AS"))
+	}
+	if len(function.Body) > 0 {
+		p.pendingBefore(function.Body[0].Pos())
+		p.newline()
+		p.statementList(function.Body)
+	}
+}
+
+// Method returnStatement prints RETURN statement with optional value. Query
+// of inline table-valued function is printed in new lines with one more level
+// of indentation, like subqueries.
+func (p *printer) returnStatement(ret *ast.ReturnStatement) {
+	p.print(p.keyword("RETURN"))
+	switch {
+	case ret.Query != nil && ret.Parens:
+		p.print(" (")
+		p.subquery(ret.Query)
+		p.print(")")
+	case ret.Query != nil:
+		p.indent++
+		p.newline()
+		p.selectStatement(ret.Query)
+		p.indent--
+	case len(ret.Value) > 0:
+		p.print(" ")
+		p.exprBlock(ret.Value)
+	}
+}
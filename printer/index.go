@@ -0,0 +1,49 @@
+package printer
+
+import (
+	"mssfmt/ast"
+	"strings"
+)
+
+// Method createIndex prints CREATE INDEX statement. Key columns are printed
+// in the first line, INCLUDE, WHERE, WITH and ON clauses start in new lines.
+func (p *printer) createIndex(index *ast.CreateIndexStatement) {
+	p.print(p.keyword("CREATE"))
+	if index.Unique {
+		p.print(" ", p.keyword("UNIQUE"))
+	}
+	if index.Clustered != "" {
+		p.print(" ", p.keyword(index.Clustered))
+	}
+	if index.Columnstore {
+		p.print(" ", p.keyword("COLUMNSTORE"))
+	}
+	p.print(" ", p.keyword("INDEX"), " ", index.Name)
+	if index.Table != "" {
+		p.print(" ", p.keyword("ON"), " ", index.Table)
+	}
+	if index.Columns != nil {
+		p.print(" (", p.exprList(index.Columns), ")")
+	}
+
+	if index.Include != nil {
+		p.newline()
+		p.print(p.keyword("INCLUDE"), " (", strings.Join(index.Include, ", "), ")")
+	}
+	if len(index.Where) > 0 {
+		p.newline()
+		p.print(p.keyword("WHERE"), " ", p.expr(index.Where))
+	}
+	if index.Options != nil {
+		p.newline()
+		p.print(p.keyword("WITH"), " (", p.optionList(index.Options), ")")
+	}
+	if len(index.FileGroup) > 0 {
+		p.newline()
+		p.print(p.keyword("ON"), " ", p.expr(index.FileGroup))
+	}
+	if len(index.Rest) > 0 {
+		p.newline()
+		p.print(p.expr(index.Rest))
+	}
+}
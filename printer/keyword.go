@@ -0,0 +1,441 @@
+package printer
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+	"strings"
+)
+
+// Keywords of T-SQL which aren't tokens, like options of statements or words
+// of window frames. They are printed in KeywordCase only in places where the
+// parser recognizes them as keywords.
+var keywords = map[string]bool{
+	"ABSENT": true, "ABSOLUTE": true, "ADD": true, "AFTER": true,
+	"ALLOW_PAGE_LOCKS": true, "ALLOW_ROW_LOCKS": true, "ANSI_DEFAULTS": true,
+	"ANSI_NULLS": true, "ANSI_NULL_DFLT_OFF": true, "ANSI_NULL_DFLT_ON": true,
+	"ANSI_PADDING": true, "ANSI_WARNINGS": true, "APPEND": true, "APPLY": true,
+	"ARITHABORT": true, "ARITHIGNORE": true, "AT": true, "AUTO": true,
+	"BASE64": true, "BINARY": true, "BY": true, "CALLED": true, "CALLER": true,
+	"CATCH": true, "COLUMNSTORE": true, "COMMIT": true, "COMMITTED": true,
+	"CONCAT_NULL_YIELDS_NULL": true, "CONTAINED": true, "CONTEXT_INFO": true,
+	"CURRENT": true, "CURSOR_CLOSE_ON_COMMIT": true, "DATA_COMPRESSION": true,
+	"DATEFIRST": true, "DATEFORMAT": true, "DEADLOCK_PRIORITY": true,
+	"DECLARE": true, "DELAYED_DURABILITY": true, "DISTRIBUTED": true,
+	"DROP_EXISTING": true, "DYNAMIC": true, "ELEMENTS": true,
+	"ENCRYPTION": true, "EXEC": true, "EXECUTE": true, "EXPLICIT": true,
+	"FAST_FORWARD": true, "FILLFACTOR": true, "FIRST": true, "FMTONLY": true,
+	"FOLLOWING": true, "FORCEPLAN": true, "FORWARD_ONLY": true, "GLOBAL": true,
+	"GROUP": true, "HASH": true, "HIGH": true, "IDENTITY_INSERT": true,
+	"IGNORE_DUP_KEY": true, "IMPLICIT_TRANSACTIONS": true, "INCLUDE": true,
+	"INCLUDE_NULL_VALUES": true, "INLINE": true, "INPUT": true,
+	"INSENSITIVE": true, "INSTEAD": true, "IO": true, "ISOLATION": true,
+	"JSON": true, "KEYSET": true, "LANGUAGE": true, "LAST": true,
+	"LEVEL": true, "LOCAL": true, "LOCK_TIMEOUT": true, "LOG": true,
+	"LOOP": true, "LOW": true, "MARK": true, "MAXDOP": true, "MERGE": true,
+	"NATIVE_COMPILATION": true, "NEXT": true, "NOCHECK": true, "NOCOUNT": true, "NOEXEC": true,
+	"NONE": true, "NORMAL": true, "NUMERIC_ROUNDABORT": true, "OF": true,
+	"ONLINE": true, "ONLY": true, "OPTIMISTIC": true, "ORDER": true,
+	"OUT": true, "OUTPUT": true, "OWNER": true, "PAD_INDEX": true,
+	"PARSEONLY": true, "PARTITION": true, "PATH": true, "PIVOT": true, "PRECEDING": true,
+	"PRIOR": true, "PROFILE": true, "QUOTED_IDENTIFIER": true,
+	"RAISERROR": true, "RANGE": true, "RAW": true, "READ": true,
+	"READONLY": true, "READ_ONLY": true, "RELATIVE": true, "REMOTE": true,
+	"REPLICATION": true, "RESULT": true, "RETURNS": true, "ROLLBACK": true,
+	"ROOT": true, "ROW": true, "SAVE": true, "SCHEMABINDING": true,
+	"SCROLL": true, "SCROLL_LOCKS": true, "SELF": true, "SERVER": true,
+	"SET": true, "SETERROR": true, "SETS": true, "SHOWPLAN_ALL": true,
+	"SHOWPLAN_TEXT": true, "SHOWPLAN_XML": true, "SORT_IN_TEMPDB": true,
+	"STATIC": true, "STATISTICS_NORECOMPUTE": true, "SYSTEM": true,
+	"SYSTEM_TIME": true, "TEXTSIZE": true, "THROW": true, "TIME": true,
+	"TO": true, "TRAN": true, "TRANSACTION": true, "TRY": true, "TYPE": true,
+	"TYPE_WARNING": true, "UNBOUNDED": true, "UNCOMMITTED": true,
+	"UNDEFINED": true, "UNPIVOT": true, "VARYING": true, "VIEW_METADATA": true, "WINDOW": true,
+	"WITHOUT_ARRAY_WRAPPER": true, "WORK": true, "XACT_ABORT": true,
+	"XMLDATA": true, "XMLSCHEMA": true, "XSINIL": true,
+}
+
+// Names of built-in T-SQL functions which aren't keywords. Names which are
+// keywords (like SUM or LEFT) are checked by isFunction.
+var builtinFunctions = map[string]bool{
+	"ABS": true, "CAST": true, "CEILING": true, "CHARINDEX": true,
+	"CHOOSE": true, "COALESCE": true, "CONCAT": true, "CONCAT_WS": true,
+	"CONVERT": true, "CUME_DIST": true, "DATALENGTH": true, "DATEADD": true,
+	"DATEDIFF": true, "DATEFROMPARTS": true, "DATENAME": true,
+	"DATEPART": true, "DAY": true, "DB_NAME": true, "DENSE_RANK": true,
+	"EOMONTH": true, "ERROR_LINE": true, "ERROR_MESSAGE": true,
+	"ERROR_NUMBER": true, "ERROR_PROCEDURE": true, "ERROR_SEVERITY": true,
+	"ERROR_STATE": true, "FIRST_VALUE": true, "FLOOR": true, "FORMAT": true,
+	"GETDATE": true, "GETUTCDATE": true, "IIF": true, "ISDATE": true,
+	"ISNULL": true, "ISNUMERIC": true, "JSON_QUERY": true,
+	"JSON_VALUE": true, "LAG": true, "LAST_VALUE": true, "LEAD": true,
+	"LEN": true, "LOWER": true, "LTRIM": true, "MONTH": true, "NEWID": true,
+	"NTILE": true, "NULLIF": true, "OBJECT_ID": true, "PATINDEX": true,
+	"PERCENTILE_CONT": true, "PERCENTILE_DISC": true, "PERCENT_RANK": true, "POWER": true, "QUOTENAME": true, "RANK": true,
+	"REPLACE": true, "REPLICATE": true, "REVERSE": true, "ROUND": true,
+	"ROW_NUMBER": true, "RTRIM": true, "SCOPE_IDENTITY": true, "SIGN": true,
+	"SPACE": true, "SQRT": true, "STR": true, "STRING_SPLIT": true,
+	"STUFF": true, "SUBSTRING": true, "SUSER_SNAME": true,
+	"SYSDATETIME": true, "SYSUTCDATETIME": true, "TRIM": true,
+	"TRY_CAST": true, "TRY_CONVERT": true, "TRY_PARSE": true, "UPPER": true,
+	"XACT_STATE": true, "YEAR": true,
+}
+
+// Names of built-in T-SQL data types.
+var builtinTypes = map[string]bool{
+	"BIGINT": true, "BINARY": true, "BIT": true, "CHAR": true,
+	"CURSOR": true, "DATE": true, "DATETIME": true, "DATETIME2": true,
+	"DATETIMEOFFSET": true, "DECIMAL": true, "FLOAT": true,
+	"GEOGRAPHY": true, "GEOMETRY": true, "HIERARCHYID": true, "IMAGE": true,
+	"INT": true, "MONEY": true, "NCHAR": true, "NTEXT": true,
+	"NUMERIC": true, "NVARCHAR": true, "REAL": true, "ROWVERSION": true,
+	"SMALLDATETIME": true, "SMALLINT": true, "SMALLMONEY": true,
+	"SQL_VARIANT": true, "SYSNAME": true, "TEXT": true, "TIME": true,
+	"TIMESTAMP": true, "TINYINT": true, "UNIQUEIDENTIFIER": true,
+	"VARBINARY": true, "VARCHAR": true, "XML": true,
+}
+
+// Function IsKeyword checks if the word is a keyword of T-SQL or a name of
+// built-in function or data type, so the printer may change its case. Other
+// identifiers are always printed as they are written.
+func IsKeyword(word string) bool {
+	upper := strings.ToUpper(word)
+	return keywords[upper] || builtinFunctions[upper] || builtinTypes[upper] ||
+		token.KeywordLookup(upper) != token.IDENT
+}
+
+// Function IsKeywordAt checks if keyword token words[id] is used as a
+// keyword. Reserved keywords always are. Other keywords (like COUNT or ROWS)
+// may be names of columns, so they are keywords only when they are followed
+// by arguments in parentheses, when they follow WITH (like WITH ROLLUP) or
+// isolation LEVEL, when they start options listed after WITH or OPTION, as
+// MAX length of data types and as ROWS of window frames, OFFSET clauses and
+// TABLESAMPLE clauses. Case of names is never changed.
+func IsKeywordAt(words ast.Expression, id int) bool {
+	tok := words[id].Token
+	if !tok.IsKeyword() || tok.IsReserved() {
+		return tok.IsKeyword()
+	}
+
+	prev, next := wordAt(words, id-1), wordAt(words, id+1)
+	cte := next.Token == token.AS && wordAt(words, id+2).Token == token.LPAREN
+	switch {
+	case prev.Token == token.PERIOD || cte:
+		return false
+	case next.Token == token.LPAREN:
+		return !precedesName(prev)
+	case prev.Token == token.WITH:
+		return true
+	case strings.EqualFold(prev.Literal, "LEVEL"):
+		return true
+	case tok == token.MAX && prev.Token == token.LPAREN:
+		return builtinTypes[strings.ToUpper(wordAt(words, id-2).Literal)]
+	case tok == token.ROWS:
+		return isRowsKeyword(words, id)
+	}
+	return isOption(words, id)
+}
+
+// Function wordAt returns words[id] or an empty word when id is out of range.
+func wordAt(words ast.Expression, id int) ast.Word {
+	if id < 0 || id >= len(words) {
+		return ast.Word{}
+	}
+	return words[id]
+}
+
+// Function precedesName checks if given word is followed by a name of table
+// or common table expression, which may be followed by a list of columns.
+func precedesName(word ast.Word) bool {
+	switch word.Token {
+	case token.WITH, token.INTO, token.TABLE, token.FROM, token.JOIN,
+		token.UPDATE:
+		return true
+	}
+	return strings.EqualFold(word.Literal, "APPLY") ||
+		strings.EqualFold(word.Literal, "MERGE") ||
+		strings.EqualFold(word.Literal, "USING")
+}
+
+// Function isRowsKeyword checks if ROWS token words[id] is used as a keyword
+// of window frame, OFFSET and FETCH clauses or TABLESAMPLE clause.
+func isRowsKeyword(words ast.Expression, id int) bool {
+	next := wordAt(words, id+1)
+	switch {
+	case next.Token == token.BETWEEN || next.Token == token.FETCH ||
+		strings.EqualFold(next.Literal, "UNBOUNDED") ||
+		strings.EqualFold(next.Literal, "CURRENT") ||
+		strings.EqualFold(next.Literal, "ONLY"):
+		return true
+	case next.Token == token.INT &&
+		strings.EqualFold(wordAt(words, id+2).Literal, "PRECEDING"):
+		return true
+	}
+
+	depth := 0
+	for id--; id >= 0; id-- {
+		switch word := words[id]; {
+		case word.Token == token.RPAREN:
+			depth++
+		case word.Token == token.LPAREN && depth == 0:
+			return wordAt(words, id-1).Token == token.TABLESAMPLE
+		case word.Token == token.LPAREN:
+			depth--
+		case depth > 0:
+		case strings.EqualFold(word.Literal, "OFFSET"):
+			return word.Token == token.IDENT
+		case word.Token == token.COMMA || word.Token.IsReserved():
+			return false
+		}
+	}
+	return false
+}
+
+// Function isOption checks if words[id] starts an option in a list of table
+// hints or query hints in parentheses after WITH or OPTION or in a list of
+// options after WITH keyword, like "WITH ENCRYPTION, RECOMPILE".
+func isOption(words ast.Expression, id int) bool {
+	prev := wordAt(words, id-1).Token
+	if prev != token.LPAREN && prev != token.COMMA {
+		return false
+	}
+
+	depth := 0
+	for id--; id >= 0; id-- {
+		switch word := words[id]; {
+		case word.Token == token.RPAREN:
+			depth++
+		case word.Token == token.LPAREN && depth == 0:
+			before := wordAt(words, id-1)
+			return before.Token == token.OPTION ||
+				before.Token == token.WITH &&
+					wordAt(words, id-2).Token != token.RPAREN
+		case word.Token == token.LPAREN:
+			depth--
+		case depth > 0:
+		case word.Token == token.WITH:
+			return wordAt(words, id+1).Token != token.LPAREN
+		case word.Token.IsReserved() && word.Token != token.AS:
+			return false
+		}
+	}
+	return false
+}
+
+// Method keyword returns given T-SQL keyword in the form in which it should be
+// printed. Words which aren't keywords, like unknown options of statements,
+// are printed as they are.
+func (p *printer) keyword(kw string) string {
+	parts := strings.Fields(kw)
+	for id, part := range parts {
+		if IsKeyword(part) {
+			parts[id] = p.convert(part, p.config.KeywordCase)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// Method optionExpr returns options of a statement or a window frame as a
+// single line string. Words of the options which are keywords without their
+// own token (like FILLFACTOR or PRECEDING) are printed in KeywordCase, except
+// words in parentheses and values after "=" or after a period. The first
+// word is a keyword even if it could be a name, like NOLOCK or RECOMPILE.
+func (p *printer) optionExpr(expr ast.Expression) string {
+	words := make(ast.Expression, len(expr))
+	copy(words, expr)
+	depth := 0
+	for id, word := range words {
+		switch {
+		case word.Token == token.LPAREN:
+			depth++
+		case word.Token == token.RPAREN:
+			depth--
+		case word.Token.IsKeyword() && id == 0:
+			words[id].Literal = p.keyword(word.Literal)
+		case word.Token == token.IDENT && depth == 0 &&
+			keywords[strings.ToUpper(word.Literal)] && (id == 0 ||
+			words[id-1].Token != token.ASSIGN &&
+				words[id-1].Token != token.PERIOD):
+			words[id].Literal = p.keyword(word.Literal)
+		}
+	}
+	return p.expr(words)
+}
+
+// Method optionList returns given lists of options as a single line string
+// separated by commas. Each item is printed by optionExpr method.
+func (p *printer) optionList(exprs []ast.Expression) string {
+	strs := make([]string, len(exprs))
+	for id, expr := range exprs {
+		strs[id] = p.optionExpr(expr)
+	}
+	return strings.Join(strs, ", ")
+}
+
+// Method convert changes case of given text. Words of the text keep their
+// spelling in the source (see spell method) when the case is PRESERVE.
+func (p *printer) convert(text string, c Case) string {
+	switch c {
+	case LOWER:
+		return strings.ToLower(text)
+	case PRESERVE:
+		parts := strings.Fields(text)
+		for id, part := range parts {
+			parts[id] = p.spell(part)
+		}
+		return strings.Join(parts, " ")
+	}
+	return strings.ToUpper(text)
+}
+
+// Method spell returns spelling of given keyword in the source. It's the
+// spelling of the next occurrence of the keyword in the current statement
+// which isn't passed yet. Keywords added by the printer take spelling of the
+// nearest passed occurrence. Keywords which don't occur in the source are
+// returned as they are.
+func (p *printer) spell(keyword string) string {
+	for id := p.spelled; id < len(p.spelling); id++ {
+		word := p.spelling[id]
+		if p.stmtEnd >= 0 && word.Pos.Offset > p.stmtEnd {
+			break
+		}
+		if strings.EqualFold(word.Literal, keyword) {
+			p.spelled = id + 1
+			return word.Literal
+		}
+	}
+	for id := p.spelled - 1; id >= 0; id-- {
+		if strings.EqualFold(p.spelling[id].Literal, keyword) {
+			return p.spelling[id].Literal
+		}
+	}
+	return keyword
+}
+
+// Method wordCase returns keyword or name of built-in function or data type
+// in given case. Keywords are represented by their tokens unless the case is
+// PRESERVE. Spaces inside multi-word keywords are normalized. Words added by
+// the printer (without position in the source) are spelled as keywords of
+// the source when the case is PRESERVE.
+func (p *printer) wordCase(word ast.Word, c Case) string {
+	text := strings.Join(strings.Fields(word.Literal), " ")
+	switch {
+	case c == PRESERVE && !word.Pos.IsValid():
+		return p.convert(text, c)
+	case c == PRESERVE:
+		return text
+	case word.Token.IsKeyword():
+		text = word.Token.String()
+	}
+	if c == LOWER {
+		return strings.ToLower(text)
+	}
+	return strings.ToUpper(text)
+}
+
+// Method typeName returns name of the data type. Only names of built-in types
+// are printed in TypeCase, names of user-defined types are identifiers.
+func (p *printer) typeName(name string) string {
+	if !builtinTypes[strings.ToUpper(name)] {
+		return name
+	}
+	return p.wordCase(ast.Word{Token: token.IDENT, Literal: name},
+		p.config.TypeCase)
+}
+
+// Function isFunctionName checks if expr[id] is a name of built-in function
+// followed by its arguments.
+func isFunctionName(expr ast.Expression, id int) bool {
+	if id+1 >= len(expr) || expr[id+1].Token != token.LPAREN ||
+		(id > 0 && expr[id-1].Token == token.PERIOD) {
+		return false
+	}
+	word := expr[id]
+	return (isFunction(word.Token) && word.Token != token.INDEX &&
+		IsKeywordAt(expr, id)) ||
+		(word.Token == token.IDENT &&
+			builtinFunctions[strings.ToUpper(word.Literal)])
+}
+
+// Function isFrameWord checks if expr[id] is a keyword of ROWS or RANGE
+// clause of a window frame which isn't a token, like RANGE, UNBOUNDED or
+// PRECEDING.
+func isFrameWord(expr ast.Expression, id int) bool {
+	if expr[id].Token != token.IDENT {
+		return false
+	}
+	switch strings.ToUpper(expr[id].Literal) {
+	case "RANGE":
+		return id+1 < len(expr) && (expr[id+1].Token == token.BETWEEN ||
+			isFrameWord(expr, id+1))
+	case "UNBOUNDED", "PRECEDING", "FOLLOWING", "CURRENT", "ROW":
+	default:
+		return false
+	}
+
+	for id--; id >= 0; id-- {
+		switch {
+		case expr[id].Token == token.ROWS ||
+			strings.EqualFold(expr[id].Literal, "RANGE"):
+			return true
+		case expr[id].Token == token.LPAREN || expr[id].Token == token.RPAREN:
+			return false
+		}
+	}
+	return false
+}
+
+// Function isWithinGroup checks if expr[id] is GROUP keyword of WITHIN GROUP
+// clause of ordered set functions, like STRING_AGG.
+func isWithinGroup(expr ast.Expression, id int) bool {
+	return id > 0 && expr[id-1].Token == token.WITHIN &&
+		strings.EqualFold(expr[id].Literal, "GROUP")
+}
+
+// Function isResultSets checks if expr[id] is SETS keyword of RESULT SETS
+// option of EXEC statement.
+func isResultSets(expr ast.Expression, id int) bool {
+	return id > 0 && strings.EqualFold(expr[id-1].Literal, "RESULT") &&
+		strings.EqualFold(expr[id].Literal, "SETS")
+}
+
+// Function isTypeName checks if expr[id] is a name of built-in data type
+// used in CAST or CONVERT function.
+func isTypeName(expr ast.Expression, id int) bool {
+	word := expr[id]
+	if word.Token != token.IDENT || id < 2 ||
+		!builtinTypes[strings.ToUpper(word.Literal)] {
+		return false
+	}
+
+	switch expr[id-1].Token {
+	case token.LPAREN:
+		return isCalled(expr, id-2, "CONVERT", "TRY_CONVERT")
+	case token.AS:
+		depth := 0
+		for open := id - 2; open > 0; open-- {
+			switch expr[open].Token {
+			case token.RPAREN:
+				depth++
+			case token.LPAREN:
+				if depth == 0 {
+					return isCalled(expr, open-1, "CAST", "TRY_CAST")
+				}
+				depth--
+			}
+		}
+	}
+	return false
+}
+
+// Function isCalled checks if expr[id] is one of given built-in functions.
+func isCalled(expr ast.Expression, id int, names ...string) bool {
+	if !isFunctionName(expr, id) {
+		return false
+	}
+	for _, name := range names {
+		if strings.EqualFold(expr[id].Literal, name) {
+			return true
+		}
+	}
+	return false
+}
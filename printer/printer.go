@@ -0,0 +1,529 @@
+// Package printer implements printing of T-SQL syntax trees in mssfmt format.
+// This package is based on Go package "printer" for printing Go syntax trees.
+package printer
+
+import (
+	"bytes"
+	"io"
+	"mssfmt/ast"
+	"mssfmt/token"
+	"strings"
+	"unicode/utf8"
+)
+
+// Fprint formats given T-SQL script in the default style and writes the
+// result to w. Comments of the script are reattached to printed nodes.
+func Fprint(w io.Writer, script *ast.Script) error {
+	return DefaultConfig.Fprint(w, script)
+}
+
+// Type printer keeps state of printing T-SQL syntax tree. Output is written
+// line by line. Field indent is current level of indentation and lineStart is
+// true when nothing has been written in the current line yet. Inner comments
+// of printed statements wait in pending until the word preceding them is
+// printed, then they are moved to lineEnd and written at the end of the line.
+// Comments which cannot be written at the end of the line wait in nextLine
+// and they are written before the next line. Field stmtStart is the output
+// offset of the current statement. Field detached is true while strings are
+// built for printing later, positions of their words are collected in
+// positions. Field indentation is a single level of indentation in the style
+// given by config and spelling contains words of the source which may be
+// keywords. Field spelled is the index of the first of them which isn't
+// passed yet and stmtEnd is the offset of the last word of the current
+// statement (-1 outside of statements). Keys of aliases are offsets of
+// aliases recorded by the parser. Field setHead is the variable of the
+// next SET statement padded to the width of variables of the surrounding SET
+// statements.
+type printer struct {
+	config      Config
+	indentation string
+	spelling    []ast.Word
+	spelled     int
+	stmtEnd     int
+	aliases     map[int]bool
+	setHead     string
+	output      bytes.Buffer
+	indent      int
+	lineStart   bool
+	comments    ast.CommentMap
+	pending     []*ast.CommentGroup
+	lineEnd     []lineComment
+	nextLine    []lineComment
+	stmtStart   int
+	detached    bool
+	positions   []token.Position
+}
+
+// Method print writes given strings into the current line. Indentation is
+// written before the first string in the line and comments waiting for the
+// next line are written before it.
+func (p *printer) print(strs ...string) {
+	for _, str := range strs {
+		if str == "" {
+			continue
+		}
+		if p.lineStart || p.output.Len() == 0 {
+			p.nextLineComments("")
+			p.output.WriteString(strings.Repeat(p.indentation, p.indent))
+			p.lineStart = false
+		}
+		p.output.WriteString(str)
+	}
+}
+
+// Method width returns width of the current line including indentation,
+// which is written before the first string in the line. Tabs of indentation
+// are counted as IndentWidth columns.
+func (p *printer) width() int {
+	out := p.output.Bytes()
+	if p.lineStart || len(out) == 0 {
+		return p.indent * p.config.IndentWidth
+	}
+	line := out[bytes.LastIndexByte(out, '\n')+1:]
+	text := bytes.TrimLeft(line, "\t")
+	return (len(line)-len(text))*p.config.IndentWidth + utf8.RuneCount(text)
+}
+
+// Method newline ends the current line. Trailing whitespace is removed.
+// Comments collected for the end of the line are written before the line
+// break, the others wait for the next line.
+func (p *printer) newline() {
+	line := bytes.TrimRight(p.output.Bytes(), " \t")
+	p.output.Truncate(len(line))
+	p.nextLine = append(p.nextLine, p.flushComments()...)
+	p.output.WriteByte('\n')
+	p.lineStart = true
+}
+
+// Method script prints all batches of the script. Batches are separated by an
+// empty line. Comments after the last word of the script are printed at the
+// end.
+func (p *printer) script(script *ast.Script) {
+	for id, batch := range script.Batches {
+		if id > 0 {
+			p.newline()
+		}
+		p.batch(batch)
+	}
+	for _, group := range p.comments.Filter(script, ast.TRAILINGCOMMENT) {
+		p.commentLines(group.List)
+	}
+}
+
+// Method batch prints statements of the batch and GO command. Comments after
+// the last statement of the batch are printed before GO.
+func (p *printer) batch(batch *ast.Batch) {
+	if len(batch.Statements) > 0 {
+		p.statementList(batch.Statements)
+		p.newline()
+	}
+	for _, group := range p.comments.Filter(batch, ast.TRAILINGCOMMENT) {
+		p.commentLines(group.List)
+	}
+
+	if batch.Go {
+		p.print(p.keyword("GO"))
+		if batch.Count != "" {
+			p.print(" ", batch.Count)
+		}
+		p.newline()
+	}
+}
+
+// Method statementList prints statements each in a separate line. New line
+// isn't printed after the last statement. Variables of consecutive SET
+// statements are aligned, so their assignment operators start in the same
+// column. When missing semicolons are added, standalone semicolons after
+// terminated statements are omitted. Other standalone semicolons before
+// common table expressions are printed in the line of WITH, like ";WITH".
+func (p *printer) statementList(stmts []ast.Statement) {
+	heads := p.setHeads(stmts)
+	printed, attached := false, false
+	for id, stmt := range stmts {
+		_, isEmpty := stmt.(*ast.EmptyStatement)
+		if isEmpty && printed && p.config.Semicolons &&
+			Terminable(stmts[id-1]) && len(p.comments[stmt]) == 0 {
+			continue
+		}
+		if printed && !attached {
+			p.newline()
+		}
+		printed = true
+		attached = isEmpty && id+1 < len(stmts) &&
+			len(p.comments[stmt]) == 0 && startsWithCTE(stmts[id+1]) &&
+			len(p.comments.Filter(stmts[id+1], ast.LEADINGCOMMENT)) == 0
+		p.setHead = heads[id]
+		p.statement(stmt)
+	}
+}
+
+// Function startsWithCTE checks if the statement starts with WITH clause of
+// common table expressions.
+func startsWithCTE(stmt ast.Statement) bool {
+	switch s := stmt.(type) {
+	case *ast.SelectStatement:
+		return len(s.With) > 0
+	case *ast.RawStatement:
+		return s.Words[0].Token == token.WITH
+	}
+	return false
+}
+
+// Method setHeads returns aligned variables of consecutive SET statements
+// followed by their operators. Heads of other statements are empty.
+func (p *printer) setHeads(stmts []ast.Statement) []string {
+	heads := make([]string, len(stmts))
+	rows := make([][]string, 0, len(stmts))
+	flush := func(end int) {
+		for id, line := range alignColumns(rows, p.config.AlignThreshold) {
+			heads[end-len(rows)+id] = line
+		}
+		rows = rows[:0]
+	}
+
+	for id, stmt := range stmts {
+		set, isSet := stmt.(*ast.SetStatement)
+		if !isSet {
+			flush(id)
+			continue
+		}
+		rows = append(rows, []string{set.Variable, set.Operator})
+	}
+	flush(len(stmts))
+	return heads
+}
+
+// Method statement prints single T-SQL statement without ending new line.
+// Leading comments of the statement are printed before it and trailing
+// comments are written at the end of its last line.
+func (p *printer) statement(stmt ast.Statement) {
+	stmtStart, stmtEnd := p.stmtStart, p.stmtEnd
+	p.stmtEnd = stmt.End().Offset
+	p.leadingComments(stmt)
+	p.innerComments(stmt)
+
+	switch s := stmt.(type) {
+	case *ast.RawStatement:
+		p.rawStatement(s)
+	case *ast.BlockStatement:
+		p.block(s)
+	case *ast.ProcedureStatement:
+		p.procedure(s)
+	case *ast.FunctionStatement:
+		p.function(s)
+	case *ast.ReturnStatement:
+		p.returnStatement(s)
+	case *ast.SelectStatement:
+		p.selectStatement(s)
+	case *ast.UpdateStatement:
+		p.updateStatement(s)
+	case *ast.ViewStatement:
+		p.view(s)
+	case *ast.TriggerStatement:
+		p.trigger(s)
+	case *ast.CreateTableStatement:
+		p.createTable(s)
+	case *ast.AlterTableStatement:
+		p.alterTable(s)
+	case *ast.DropStatement:
+		p.dropStatement(s)
+	case *ast.CreateIndexStatement:
+		p.createIndex(s)
+	case *ast.IfStatement:
+		p.ifStatement(s)
+	case *ast.WhileStatement:
+		p.whileStatement(s)
+	case *ast.BreakStatement:
+		p.print(p.keyword("BREAK"))
+	case *ast.ContinueStatement:
+		p.print(p.keyword("CONTINUE"))
+	case *ast.GotoStatement:
+		p.print(p.keyword("GOTO"), " ", s.Label)
+	case *ast.LabelStatement:
+		p.print(s.Label, ":")
+	case *ast.TryCatchStatement:
+		p.tryCatchStatement(s)
+	case *ast.TransactionStatement:
+		p.transactionStatement(s)
+	case *ast.ThrowStatement:
+		p.throwStatement(s)
+	case *ast.RaiserrorStatement:
+		p.raiserrorStatement(s)
+	case *ast.DeclareStatement:
+		p.declareStatement(s)
+	case *ast.SetStatement:
+		p.setStatement(s)
+	case *ast.SetOptionStatement:
+		p.setOptionStatement(s)
+	case *ast.ExecStatement:
+		p.execStatement(s)
+	case *ast.DeclareCursorStatement:
+		p.declareCursor(s)
+	case *ast.CursorStatement:
+		p.cursorStatement(s)
+	case *ast.FetchStatement:
+		p.fetchStatement(s)
+	}
+
+	if stmt.Terminated() || p.config.Semicolons && Terminable(stmt) {
+		p.print(";")
+	}
+	if stmt.Terminated() {
+		p.passed(stmt.End())
+	}
+	p.trailingComments(stmt)
+	p.stmtStart, p.stmtEnd = stmtStart, stmtEnd
+}
+
+// Function Terminable checks if semicolon is added after the statement when
+// missing semicolons are added. Blocks, control-of-flow statements and
+// definitions of modules end with statements they contain, so they aren't
+// terminated. Statements which aren't fully parsed (raw statements, values of
+// RETURN and unsupported actions of ALTER TABLE) aren't terminated either,
+// because their ends are only guessed. Raw INSERT, DELETE and MERGE
+// statements which are complete (see ast.RawStatement) are the exception.
+func Terminable(stmt ast.Statement) bool {
+	switch s := stmt.(type) {
+	case *ast.EmptyStatement, *ast.BlockStatement, *ast.IfStatement,
+		*ast.WhileStatement, *ast.TryCatchStatement, *ast.LabelStatement,
+		*ast.ProcedureStatement, *ast.FunctionStatement,
+		*ast.TriggerStatement:
+		return false
+	case *ast.RawStatement:
+		return s.Complete
+	case *ast.ReturnStatement:
+		return s.Value == nil
+	case *ast.AlterTableStatement:
+		return s.Action != ast.OTHERACTION
+	}
+	return true
+}
+
+// Method rawStatement prints words of statement which isn't parsed. Line
+// breaks of the source are kept and lines following the first one get one
+// more level of indentation. Statement written in a single line is printed
+// by valuesBlock, so its table value constructor may be split into rows.
+func (p *printer) rawStatement(raw *ast.RawStatement) {
+	lines := sourceLines(raw.Words)
+	if len(lines) == 1 {
+		p.valuesBlock(raw.Words)
+		return
+	}
+	p.print(p.expr(lines[0]))
+	p.indent++
+	for _, line := range lines[1:] {
+		p.newline()
+		p.print(p.expr(line))
+	}
+	p.indent--
+}
+
+// Function sourceLines splits words into lines in which they were written in
+// the source.
+func sourceLines(words ast.Expression) []ast.Expression {
+	lines := make([]ast.Expression, 0, 4)
+	start := 0
+	for id := 1; id < len(words); id++ {
+		if words[id].Pos.Line != words[id-1].Pos.Line {
+			lines = append(lines, words[start:id])
+			start = id
+		}
+	}
+	return append(lines, words[start:])
+}
+
+// Method block prints BEGIN ... END block with indented statements. Comments
+// preceding END are printed inside the block.
+func (p *printer) block(block *ast.BlockStatement) {
+	p.print(p.keyword("BEGIN"))
+	p.indentedList(block.Statements)
+	p.blockEnd(block.End())
+	p.print(p.keyword("END"))
+}
+
+// Method blockEnd ends the last line of a block. Comments which precede the
+// end of the block are printed with the indentation of the block statements.
+func (p *printer) blockEnd(end token.Position) {
+	p.indent++
+	p.pendingBefore(end)
+	p.keepIndent()
+	p.indent--
+	p.newline()
+}
+
+// Method createMode prints CREATE, ALTER or CREATE OR ALTER keywords.
+func (p *printer) createMode(mode ast.CreateMode) {
+	switch mode {
+	case ast.CREATE:
+		p.print(p.keyword("CREATE"))
+	case ast.ALTER:
+		p.print(p.keyword("ALTER"))
+	case ast.CREATEORALTER:
+		p.print(p.keyword("CREATE OR ALTER"))
+	}
+}
+
+// Method dataType returns T-SQL data type as a string, like NVARCHAR(MAX) or
+// DECIMAL(18, 2).
+func (p *printer) dataType(dataType *ast.DataType) string {
+	name := p.typeName(dataType.Name)
+	if len(dataType.Params) == 0 {
+		return name
+	}
+
+	params := make([]string, len(dataType.Params))
+	for id, param := range dataType.Params {
+		params[id] = p.word(param)
+	}
+	return name + "(" + strings.Join(params, ", ") + ")"
+}
+
+// Method listLines prints lines of list items, each in a new line. Lines are
+// separated by commas placed as itemPrefix and itemSuffix methods say. For
+// each item positions contain positions returned by detach method followed by
+// the position of the last word of the item, so comments of the item are
+// written at the end of its line. Comments after the last item which are
+// printed in separate lines keep indentation of the list.
+func (p *printer) listLines(lines []string, positions [][]token.Position) {
+	for id, line := range lines {
+		p.newline()
+		p.print(p.itemPrefix(id, len(lines)), line,
+			p.itemSuffix(id, len(lines)))
+		words := positions[id]
+		p.replay(words[:len(words)-1])
+		p.passedItem(words[len(words)-1], id < len(lines)-1)
+	}
+	p.keepIndent()
+}
+
+// Method itemPrefix returns string printed before id-th of n list items in
+// separate lines. With leading commas each item except the first one starts
+// with comma and the first item is padded, so all items are aligned.
+func (p *printer) itemPrefix(id, n int) string {
+	switch {
+	case !p.config.LeadingCommas || n < 2:
+		return ""
+	case id == 0:
+		return "  "
+	}
+	return ", "
+}
+
+// Method itemSuffix returns string printed after id-th of n list items in
+// separate lines. With trailing commas each item except the last one is
+// followed by comma.
+func (p *printer) itemSuffix(id, n int) string {
+	if p.config.LeadingCommas || id == n-1 {
+		return ""
+	}
+	return ","
+}
+
+// Method inline prints list items in the current line, separated by commas
+// and surrounded by prefix and suffix. Positions are positions of items as in
+// listLines method. Nothing is printed and false is returned when the list
+// doesn't fit into the line or there are comments inside it or directly
+// around it, which would end up after other words.
+func (p *printer) inline(items []string, positions [][]token.Position,
+	prefix, suffix string) bool {
+	line := prefix + strings.Join(items, ", ") + suffix
+	if len(items) > 0 {
+		first, last := positions[0][0], positions[len(positions)-1]
+		if p.commentsBetween(first, last[len(last)-1]) ||
+			p.commentsAround(first, last[len(last)-1]) {
+			return false
+		}
+	}
+	if !p.fits(line) {
+		return false
+	}
+	p.print(line)
+	for _, words := range positions {
+		p.replay(words)
+	}
+	return true
+}
+
+// Method fits checks if given string fits into the current line.
+func (p *printer) fits(str string) bool {
+	return p.width()+utf8.RuneCountInString(str) <= p.config.LineWidth
+}
+
+// Function joinCells joins cells of each row into a single line without
+// alignment.
+func joinCells(rows [][]string) []string {
+	return alignColumns(rows, 0)
+}
+
+// Function exprEnd returns position of the last word of the expression.
+func exprEnd(expr ast.Expression) token.Position {
+	if len(expr) == 0 {
+		return token.Position{}
+	}
+	return expr[len(expr)-1].Pos
+}
+
+// Function alignColumns joins cells of given rows into lines in the way that
+// cells of the same column are aligned, like text/tabwriter does. Cells wider
+// than the narrowest cell of their column by more than threshold are followed
+// by a single space and don't move the column to the right, so zero threshold
+// disables alignment. Trailing empty cells are omitted.
+func alignColumns(rows [][]string, threshold int) []string {
+	widths := columnWidths(rows, threshold)
+	lines := make([]string, len(rows))
+	for rowId, row := range rows {
+		var line strings.Builder
+		last := len(row) - 1
+		for last > 0 && row[last] == "" {
+			last--
+		}
+		for id := 0; id <= last; id++ {
+			line.WriteString(row[id])
+			if id < last {
+				padding := widths[id] - utf8.RuneCountInString(row[id])
+				if padding < 0 {
+					padding = 0
+				}
+				line.WriteString(strings.Repeat(" ", 1+padding))
+			}
+		}
+		lines[rowId] = line.String()
+	}
+	return lines
+}
+
+// Function columnWidths returns widths of aligned columns of given rows.
+// Width of a column is the width of its widest cell which isn't wider than
+// the narrowest non-empty cell by more than threshold. The last cells of rows
+// don't count.
+func columnWidths(rows [][]string, threshold int) []int {
+	narrowest := make([]int, 0, 5)
+	for _, row := range rows {
+		if len(row) == 0 {
+			continue
+		}
+		for id, cell := range row[:len(row)-1] {
+			if id == len(narrowest) {
+				narrowest = append(narrowest, -1)
+			}
+			width := utf8.RuneCountInString(cell)
+			if width > 0 && (narrowest[id] < 0 || width < narrowest[id]) {
+				narrowest[id] = width
+			}
+		}
+	}
+
+	widths := make([]int, len(narrowest))
+	for _, row := range rows {
+		if len(row) == 0 {
+			continue
+		}
+		for id, cell := range row[:len(row)-1] {
+			width := utf8.RuneCountInString(cell)
+			if width > widths[id] && width <= narrowest[id]+threshold {
+				widths[id] = width
+			}
+		}
+	}
+	return widths
+}
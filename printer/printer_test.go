@@ -0,0 +1,1071 @@
+package printer
+
+import (
+	"bytes"
+	"mssfmt/parser"
+	"mssfmt/scanner"
+	"testing"
+)
+
+// Test for printing CREATE PROCEDURE with aligned parameters.
+func TestPrintProcedure(t *testing.T) {
+	src := `create or alter procedure dbo.usp_Test @p1 int = 0 output,
+	@LongName dbo.TVP readonly, @s nvarchar(max)=N'x'
+	with recompile as begin set nocount on; select sum(a) from t where x<>@p1 end`
+	exp := `CREATE OR ALTER PROCEDURE dbo.usp_Test
+    @p1       int           = 0 OUTPUT,
+    @LongName dbo.TVP       READONLY,
+    @s        nvarchar(MAX) = N'x'
+WITH RECOMPILE
+AS
+BEGIN
+    SET NOCOUNT ON;
+    SELECT SUM(a)
+    FROM t
+    WHERE x <> @p1
+END
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for printing batches, parameters in parentheses and empty blocks.
+func TestPrintBatches(t *testing.T) {
+	src := "alter proc x(@a int) as begin end\ngo\nselect -1, f(a - 1)"
+	exp := `ALTER PROC x
+(
+    @a int
+)
+AS
+BEGIN
+END
+GO
+
+SELECT
+    -1,
+    f(a - 1)
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for aligning columns.
+func TestAlignColumns(t *testing.T) {
+	rows := [][]string{
+		{"@a", "int", "= 1"},
+		{"@abc", "varchar(10)", ""},
+		{"@ąę", "int", "OUTPUT"},
+	}
+	exp := []string{
+		"@a   int         = 1",
+		"@abc varchar(10)",
+		"@ąę  int         OUTPUT",
+	}
+
+	for id, line := range alignColumns(rows, 20) {
+		if line != exp[id] {
+			t.Errorf("Expected line [%s], got: [%s]", exp[id], line)
+		}
+	}
+}
+
+// Function checkPrint parses src and compares printed script with exp.
+func checkPrint(t *testing.T, src, exp string) {
+	t.Helper()
+	checkPrintConfig(t, &DefaultConfig, src, exp)
+}
+
+// Function checkPrintConfig parses given source and compares result of
+// printing it in the style described by cfg with expected output.
+func checkPrintConfig(t *testing.T, cfg *Config, src, exp string) {
+	t.Helper()
+	var s scanner.Scanner
+	var p parser.Parser
+	s.Init("test", []byte(src))
+	p.Init("test", parser.ScanWords(s))
+
+	var out bytes.Buffer
+	if err := cfg.Fprint(&out, p.Script()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if out.String() != exp {
+		t.Errorf("Expected:\n%s\ngot:\n%s", exp, out.String())
+	}
+}
+
+// Test for printing all kinds of functions.
+func TestPrintFunction(t *testing.T) {
+	src := `create function dbo.f() returns int as begin return 1 end
+	GO
+	create function dbo.g(@id int) returns table with schemabinding
+	return (select a from t where id = @id)
+	GO
+	alter function dbo.h() returns @t table (id int, name varchar(10)) as
+	begin return end`
+	exp := `CREATE FUNCTION dbo.f()
+RETURNS int
+AS
+BEGIN
+    RETURN 1
+END
+GO
+
+CREATE FUNCTION dbo.g
+(
+    @id int
+)
+RETURNS TABLE
+WITH SCHEMABINDING
+RETURN (
+    SELECT a
+    FROM t
+    WHERE id = @id
+)
+GO
+
+ALTER FUNCTION dbo.h()
+RETURNS @t TABLE
+(
+    id   int,
+    name varchar(10)
+)
+AS
+BEGIN
+    RETURN
+END
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for printing SELECT statements with joins, common table expressions,
+// derived tables and set operators.
+func TestPrintSelect(t *testing.T) {
+	src := `with cte (id, n) as (select id, count(*) from dbo.t group by id)
+	select distinct top 10 c.id, x.name as Name into #tmp from cte c
+	inner join dbo.x as x with (nolock) on x.id = c.id left outer join
+	(select id from dbo.y where flag = 1) y on y.id = c.id cross apply
+	dbo.f(c.id) f, dbo.z where c.n > 1 order by c.id desc option (recompile)
+	select a from t union all select b from u;`
+	exp := `WITH cte (id, n) AS (
+    SELECT
+        id,
+        COUNT(*)
+    FROM dbo.t
+    GROUP BY id
+)
+SELECT DISTINCT TOP 10
+    c.id,
+    x.name AS Name
+INTO #tmp
+FROM cte c
+INNER JOIN dbo.x AS x WITH (NOLOCK)
+    ON x.id = c.id
+LEFT OUTER JOIN (
+    SELECT id
+    FROM dbo.y
+    WHERE flag = 1
+) y
+    ON y.id = c.id
+CROSS APPLY dbo.f(c.id) f,
+    dbo.z
+WHERE c.n > 1
+ORDER BY c.id DESC
+OPTION (RECOMPILE)
+SELECT a
+FROM t
+UNION ALL
+SELECT b
+FROM u;
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for printing CREATE VIEW and CREATE TRIGGER statements.
+func TestPrintViewAndTrigger(t *testing.T) {
+	src := `create view dbo.v (a, b) with schemabinding as select a, b from dbo.t
+	where a > 0 with check option
+	GO
+	create or alter trigger dbo.tr on dbo.t with execute as owner after insert,
+	update not for replication as begin set nocount on; end`
+	exp := `CREATE VIEW dbo.v (a, b)
+WITH SCHEMABINDING
+AS
+SELECT
+    a,
+    b
+FROM dbo.t
+WHERE a > 0
+WITH CHECK OPTION
+GO
+
+CREATE OR ALTER TRIGGER dbo.tr ON dbo.t
+WITH EXECUTE AS OWNER
+AFTER INSERT, UPDATE
+NOT FOR REPLICATION
+AS
+BEGIN
+    SET NOCOUNT ON;
+END
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for printing CREATE TABLE with aligned column definitions.
+func TestPrintCreateTable(t *testing.T) {
+	src := `create table dbo.Orders (OrderId int identity(1,1) not null
+	constraint PK_Orders primary key clustered, CustomerName nvarchar(max) null
+	default N'x', Total as Price*Qty persisted, Price decimal(18,2),
+	constraint FK_Orders_C foreign key (CustomerId) references dbo.C (Id) on
+	delete cascade, check (Price > 0)) on [PRIMARY]`
+	exp := `CREATE TABLE dbo.Orders
+(
+    OrderId      int            IDENTITY(1, 1) NOT NULL CONSTRAINT PK_Orders PRIMARY KEY CLUSTERED,
+    CustomerName nvarchar(MAX)  NULL DEFAULT N'x',
+    Total        AS Price * Qty persisted,
+    Price        decimal(18, 2),
+    CONSTRAINT FK_Orders_C FOREIGN KEY (CustomerId) REFERENCES dbo.C (Id) ON DELETE cascade,
+    CHECK (Price > 0)
+)
+ON [PRIMARY]
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for printing ALTER TABLE and DROP statements.
+func TestPrintAlterTableAndDrop(t *testing.T) {
+	src := `alter table dbo.t add a int null, longName varchar(10) not null;
+	alter table dbo.t with nocheck add constraint fk foreign key (a)
+	references dbo.u (id)
+	alter table t alter column a bigint
+	alter table t drop constraint if exists df, column a, b
+	alter table t nocheck constraint all
+	drop procedure if exists dbo.p, dbo.q`
+	exp := `ALTER TABLE dbo.t
+ADD
+    a        int         NULL,
+    longName varchar(10) NOT NULL;
+ALTER TABLE dbo.t WITH NOCHECK
+ADD CONSTRAINT fk FOREIGN KEY (a) REFERENCES dbo.u (id)
+ALTER TABLE t
+ALTER COLUMN a bigint
+ALTER TABLE t
+DROP CONSTRAINT IF EXISTS df, COLUMN a, b
+ALTER TABLE t
+NOCHECK CONSTRAINT all
+DROP PROCEDURE IF EXISTS dbo.p, dbo.q
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for printing CREATE INDEX statements.
+func TestPrintCreateIndex(t *testing.T) {
+	src := `create unique nonclustered index ix on dbo.t(a asc,b desc) include(c)
+	where a IS not null with(fillfactor=90,online=on) on [PRIMARY];
+	create clustered columnstore index cci on dbo.t`
+	exp := `CREATE UNIQUE NONCLUSTERED INDEX ix ON dbo.t (a ASC, b DESC)
+INCLUDE (c)
+WHERE a IS NOT NULL
+WITH (FILLFACTOR = 90, ONLINE = ON)
+ON [PRIMARY];
+CREATE CLUSTERED COLUMNSTORE INDEX cci ON dbo.t
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for printing control-of-flow statements with nested blocks.
+func TestPrintControlFlow(t *testing.T) {
+	src := `while @i<@n begin set @i=@i+1
+	if @i%2=0 continue else if @i>10 begin break end else print @i
+	if @i = 3 goto done end
+	done: return -1`
+	exp := `WHILE @i < @n
+BEGIN
+    SET @i = @i + 1
+    IF @i % 2 = 0
+        CONTINUE
+    ELSE IF @i > 10
+    BEGIN
+        BREAK
+    END
+    ELSE
+        print @i
+    IF @i = 3
+        GOTO done
+END
+done:
+RETURN -1
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for printing TRY ... CATCH and transaction statements.
+func TestPrintTryCatch(t *testing.T) {
+	src := `begin try begin transaction t1 with mark 'm'
+	commit tran t1 end try
+	begin catch if @@trancount>0 rollback; throw; end catch
+	raiserror('x', 16, 1) with nowait`
+	exp := `BEGIN TRY
+    BEGIN TRANSACTION t1 WITH MARK 'm'
+    COMMIT TRAN t1
+END TRY
+BEGIN CATCH
+    IF @@trancount > 0
+        ROLLBACK;
+    THROW;
+END CATCH
+RAISERROR('x', 16, 1) WITH NOWAIT
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for printing DECLARE and SET statements.
+func TestPrintDeclareAndSet(t *testing.T) {
+	src := `declare @a int=1,@name nvarchar(max),@d as decimal(18,2)=@x*2
+	declare @t table(id int not null, value varchar(10))
+	declare @i int
+	set nocount on
+	set @i+=1`
+	exp := `DECLARE
+    @a    int               = 1,
+    @name nvarchar(MAX),
+    @d    AS decimal(18, 2) = @x * 2
+DECLARE @t TABLE
+(
+    id    int         NOT NULL,
+    value varchar(10)
+)
+DECLARE @i int
+SET NOCOUNT ON
+SET @i += 1
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for printing long values of variables and of RETURN statement. They are
+// broken as other expressions and their CASE expressions are split.
+func TestPrintLongValues(t *testing.T) {
+	src := `declare @a int = 1, @b varchar(5) = case when @a = 1 then 'one'
+	when @a = 2 then 'two' end, @c int
+	declare @long nvarchar(max) = @firstName + N' and ' + @lastName + N' x'
+	return case when @a = 1 then 'one' when @a = 2 then 'two' end`
+	cfg := DefaultConfig
+	cfg.LineWidth = 60
+	exp := `DECLARE
+    @a int = 1,
+    @b varchar(5) = CASE
+                        WHEN @a = 1 THEN 'one'
+                        WHEN @a = 2 THEN 'two'
+                    END,
+    @c int
+DECLARE @long nvarchar(MAX) = @firstName
+    + N' and '
+    + @lastName
+    + N' x'
+RETURN CASE
+           WHEN @a = 1 THEN 'one'
+           WHEN @a = 2 THEN 'two'
+       END
+`
+	checkPrintConfig(t, &cfg, src, exp)
+}
+
+// Test for printing table variables declared together with scalar variables.
+// Names of all variables are aligned.
+func TestPrintDeclareTableVariable(t *testing.T) {
+	src := `declare @a int = 1, @t table (id int), @name varchar(20)
+	declare @t table`
+	exp := `DECLARE
+    @a    int         = 1,
+    @t    TABLE
+    (
+        id int
+    ),
+    @name varchar(20)
+declare @t TABLE
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for printing EXEC statements. Long argument lists are printed one
+// argument per line.
+func TestPrintExec(t *testing.T) {
+	src := `exec dbo.p @a=1,@b=@x output
+	exec @rc = dbo.usp_LongProcedureName @FirstParameter = 1,
+	@SecondParameter = N'value', @Third = @out out with recompile
+	execute (@sql)`
+	exp := `EXEC dbo.p @a = 1, @b = @x OUTPUT
+EXEC @rc = dbo.usp_LongProcedureName
+    @FirstParameter  = 1,
+    @SecondParameter = N'value',
+    @Third           = @out OUT
+WITH RECOMPILE
+EXECUTE (@sql)
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for printing RESULT SETS option of EXEC statements as keywords with a
+// space before definitions of result sets.
+func TestPrintExecResultSets(t *testing.T) {
+	src := `exec p with result sets ((a int, b varchar(10)))
+	exec p with recompile, result sets none`
+	exp := `EXEC p WITH RESULT SETS ((a int, b varchar(10)))
+EXEC p WITH RECOMPILE, RESULT SETS NONE
+`
+	checkPrint(t, src, exp)
+
+	cfg := DefaultConfig
+	cfg.KeywordCase = LOWER
+	exp = `exec p with result sets ((a int, b varchar(10)))
+exec p with recompile, result sets none
+`
+	checkPrintConfig(t, &cfg, src, exp)
+}
+
+// Test for printing cursor statements.
+func TestPrintCursor(t *testing.T) {
+	src := `declare c cursor local fast_forward for select a from t
+	for update of a
+	open c fetch next from c into @a
+	while @@fetch_status=0 fetch absolute -1 from c
+	close c deallocate c`
+	exp := `DECLARE c CURSOR LOCAL FAST_FORWARD FOR
+    SELECT a
+    FROM t
+FOR UPDATE OF a
+OPEN c
+FETCH NEXT FROM c INTO @a
+WHILE @@fetch_status = 0
+    FETCH ABSOLUTE -1 FROM c
+CLOSE c
+DEALLOCATE c
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for printing window functions and WINDOW clause. Long OVER clauses
+// are split into separate lines.
+func TestPrintWindow(t *testing.T) {
+	src := `select a, row_number() over(partition by a, b order by c desc rows
+	between unbounded preceding and current row) as rn, sum(v) over (w) as s
+	from t window w as (partition by a order by b)`
+	exp := `SELECT
+    a,
+    ROW_NUMBER() OVER (
+        PARTITION BY a, b
+        ORDER BY c DESC
+        ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW
+    ) AS rn,
+    SUM(v) OVER (w) AS s
+FROM t
+WINDOW w AS (PARTITION BY a ORDER BY b)
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for printing WITHIN GROUP clause of ordered set functions as a
+// keyword.
+func TestPrintWithinGroup(t *testing.T) {
+	src := `select string_agg(x, ',') within group (order by x) as s from t`
+	exp := "SELECT STRING_AGG(x, ',') WITHIN GROUP (ORDER BY x) AS s\nFROM t\n"
+	checkPrint(t, src, exp)
+
+	cfg := DefaultConfig
+	cfg.KeywordCase = LOWER
+	exp = "select STRING_AGG(x, ',') within group (order by x) as s\nfrom t\n"
+	checkPrintConfig(t, &cfg, src, exp)
+}
+
+// Test for printing CASE expressions. Each WHEN is printed in a separate line
+// aligned under CASE keyword.
+func TestPrintCase(t *testing.T) {
+	src := `select case when x = 1 then 'one' when x = 2 then case y when 1
+	then 'a' else 'b' end else 'many' end as label, case when z > 0 then 1 end
+	from t where a = case b when 1 then 2 when 3 then 4 end`
+	exp := `SELECT
+    CASE
+        WHEN x = 1 THEN 'one'
+        WHEN x = 2 THEN CASE y WHEN 1 THEN 'a' ELSE 'b' END
+        ELSE 'many'
+    END AS label,
+    CASE WHEN z > 0 THEN 1 END
+FROM t
+WHERE a = CASE b
+              WHEN 1 THEN 2
+              WHEN 3 THEN 4
+          END
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for printing PIVOT and UNPIVOT operators in separate lines below table
+// sources.
+func TestPrintPivot(t *testing.T) {
+	src := `select * from sales s pivot (sum(amount) for year in ([2019],[2020]))
+	as p join wide w unpivot (v for col in (a,b)) u on u.id = p.id`
+	exp := `SELECT *
+FROM sales s
+    PIVOT (SUM(amount) FOR year IN ([2019], [2020])) AS p
+JOIN wide w
+    UNPIVOT (v FOR col IN (a, b)) u
+    ON u.id = p.id
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for printing WITH clause of OPENJSON function before its alias.
+func TestPrintOpenJSON(t *testing.T) {
+	src := `select j.id from t cross apply OPENJSON(t.js) with (id int '$.id',
+	v nvarchar(max) '$.v' as JSON) j`
+	exp := `SELECT j.id
+FROM t
+CROSS APPLY OPENJSON(t.js) WITH (id int '$.id', v nvarchar(MAX) '$.v' AS JSON) j
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for printing FOR SYSTEM_TIME clauses next to table names and FOR XML
+// or FOR JSON clauses in a separate line.
+func TestPrintForClauses(t *testing.T) {
+	src := `select a from t for system_time as of @d x join u for system_time
+	contained in (@a,@b) on u.id = x.id order by a for json path,root('x')
+	select b from v for system_time all for xml raw('r'), elements xsinil`
+	exp := `SELECT a
+FROM t FOR SYSTEM_TIME AS OF @d x
+JOIN u FOR SYSTEM_TIME CONTAINED IN (@a, @b)
+    ON u.id = x.id
+ORDER BY a
+FOR JSON PATH, ROOT('x')
+SELECT b
+FROM v FOR SYSTEM_TIME ALL
+FOR XML RAW('r'), ELEMENTS XSINIL
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for reattaching comments to printed statements. Leading comments are
+// printed in separate lines, trailing and inner comments at the end of lines.
+func TestPrintComments(t *testing.T) {
+	src := `/*
+ * Header
+ */
+-- second line
+select a, -- first
+b /* second */, c
+from dbo.t x -- table
+join u on u.id = x.id
+where a = 1 -- filter
+order by a; -- trailing
+if @a = 1 -- check
+begin
+	-- before set
+	set @b = 2
+	-- at the end
+end
+GO -- after go
+-- end of script`
+	exp := `/*
+ * Header
+ */
+-- second line
+SELECT
+    a, -- first
+    b, /* second */
+    c
+FROM dbo.t x -- table
+JOIN u
+    ON u.id = x.id
+WHERE a = 1 -- filter
+ORDER BY a; -- trailing
+IF @a = 1 -- check
+BEGIN
+    -- before set
+    SET @b = 2
+    -- at the end
+END
+GO
+-- after go
+-- end of script
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for comments between items of lists. Lists with comments aren't
+// printed in a single line and each comment follows the item before it.
+func TestPrintListComments(t *testing.T) {
+	src := `exec p @a = 1, -- c12
+	@b = 2
+select a, -- c1
+	case when x = 1 then 1 when x = 2 then 2 end as c
+from t
+where b in (1, -- c11
+	2)`
+	exp := `EXEC p
+    @a = 1, -- c12
+    @b = 2
+SELECT
+    a, -- c1
+    CASE
+        WHEN x = 1 THEN 1
+        WHEN x = 2 THEN 2
+    END AS c
+FROM t
+WHERE b IN (
+    1, -- c11
+    2
+)
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for printing in styles with different indentation and line width.
+func TestPrintConfig(t *testing.T) {
+	src := `if @a = 1 begin select a, b from t where x = case when y = 1 then 2 else 3 end end`
+	exp := `IF @a = 1
+BEGIN
+  SELECT
+    a,
+    b
+  FROM t
+  WHERE x = CASE WHEN y = 1 THEN 2 ELSE 3 END
+END
+`
+	checkPrintConfig(t, &Config{IndentWidth: 2, LineWidth: 80}, src, exp)
+
+	exp = "IF @a = 1\nBEGIN\n\tSELECT\n\t\ta,\n\t\tb\n\tFROM t\n" +
+		"\tWHERE x = CASE\n" +
+		"\t              WHEN y = 1 THEN 2\n" +
+		"\t              ELSE 3\n" +
+		"\t          END\nEND\n"
+	checkPrintConfig(t, &Config{IndentWidth: 4, UseTabs: true, LineWidth: 20},
+		src, exp)
+}
+
+// Test for printing keywords, names of functions and data types in different
+// cases. Identifiers, strings and delimited names keep their case.
+func TestPrintKeywordCase(t *testing.T) {
+	src := `Create Procedure dbo.Usp @P Int As Begin Set NoCount On
+	Select Count(*), isnull(Col, 'Str'), Cast(Col As VarChar(10)), [Select],
+	convert(DateTime, X), dbo.Year(X) From [Tab] Left Join T2 On 1 = 1 End`
+	cfg := DefaultConfig
+	cfg.KeywordCase, cfg.FunctionCase, cfg.TypeCase = LOWER, UPPER, LOWER
+	exp := `create procedure dbo.Usp
+    @P int
+as
+begin
+    set nocount on
+    select
+        COUNT(*),
+        ISNULL(Col, 'Str'),
+        CAST(Col as varchar(10)),
+        [Select],
+        CONVERT(datetime, X),
+        dbo.Year(X)
+    from [Tab]
+    left join T2
+        on 1 = 1
+end
+`
+	checkPrintConfig(t, &cfg, src, exp)
+
+	cfg.KeywordCase, cfg.FunctionCase, cfg.TypeCase = PRESERVE, PRESERVE,
+		PRESERVE
+	exp = `Create Procedure dbo.Usp
+    @P Int
+As
+Begin
+    Set NoCount On
+    Select
+        Count(*),
+        isnull(Col, 'Str'),
+        Cast(Col As VarChar(10)),
+        [Select],
+        convert(DateTime, X),
+        dbo.Year(X)
+    From [Tab]
+    Left Join T2
+        On 1 = 1
+End
+`
+	checkPrintConfig(t, &cfg, src, exp)
+
+	src = "select a from t\nSELECT b FROM u"
+	exp = "select a\nfrom t\nSELECT b\nFROM u\n"
+	checkPrintConfig(t, &cfg, src, exp)
+}
+
+// Test for printing keywords which aren't tokens, like options or words of
+// window frames. Words after a period keep their case.
+func TestPrintOptionKeywords(t *testing.T) {
+	src := `set transaction isolation level read committed
+	select t.Count, dbo.Sum(x), percentile_cont(0.5) within group (order by a)
+	over (), sum(a) over (order by b rows between unbounded preceding and
+	current row) from t option (maxdop 1)`
+	exp := `SET TRANSACTION ISOLATION LEVEL READ COMMITTED
+SELECT
+    t.Count,
+    dbo.Sum(x),
+    PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY a) OVER (),
+    SUM(a) OVER (ORDER BY b ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)
+FROM t
+OPTION (MAXDOP 1)
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for printing keywords which are used as names of columns. They keep
+// their case, while the same keywords used as keywords are converted.
+func TestPrintKeywordNames(t *testing.T) {
+	src := `create table t (Count int, Rows nvarchar(max));
+	select Count, Max - 1, count(*) from t with (nolock, rowlock)
+	order by Rows offset 5 rows;
+	select a from t group by a with rollup option (recompile)`
+	exp := `CREATE TABLE t
+(
+    Count int,
+    Rows nvarchar(MAX)
+);
+SELECT
+    Count,
+    Max - 1,
+    COUNT(*)
+FROM t WITH (NOLOCK, ROWLOCK)
+ORDER BY Rows
+offset 5 ROWS;
+SELECT a
+FROM t
+GROUP BY a WITH ROLLUP
+OPTION (RECOMPILE)
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for printing lists with leading commas. Lists which don't fit into the
+// line are printed one item per line.
+func TestPrintLeadingCommas(t *testing.T) {
+	src := `create procedure dbo.p @a int, @b varchar(10) = 'x' as
+	declare @x int = 1, @y int
+	select a, b -- b
+	, c from t group by a, b order by LongColumnName, OtherColumnName
+	insert into t values (1, 2), (3, 4)
+	create table dbo.t (id int not null, name varchar(10))`
+	cfg := DefaultConfig
+	cfg.LeadingCommas, cfg.LineWidth = true, 30
+	exp := `CREATE PROCEDURE dbo.p
+      @a int
+    , @b varchar(10) = 'x'
+AS
+DECLARE
+      @x int = 1
+    , @y int
+SELECT
+      a
+    , b -- b
+    , c
+FROM t
+GROUP BY a, b
+ORDER BY
+      LongColumnName
+    , OtherColumnName
+INSERT INTO t VALUES
+      (1, 2)
+    , (3, 4)
+CREATE TABLE dbo.t
+(
+      id   int         NOT NULL
+    , name varchar(10)
+)
+`
+	checkPrintConfig(t, &cfg, src, exp)
+}
+
+// Test for printing short lists in a single line.
+func TestPrintCollapseLists(t *testing.T) {
+	src := `create function dbo.f(@a int, @b int) returns @t table (id int, v int)
+	as begin return end
+	GO
+	declare @x int = 1, @y int
+	select a, b from (values (1, 2), (3, 4)) v(a, b)
+	select a, b -- b
+	, c from t
+	alter table t add a int, b int`
+	cfg := DefaultConfig
+	cfg.CollapseLists = true
+	exp := `CREATE FUNCTION dbo.f(@a int, @b int)
+RETURNS @t TABLE (id int, v int)
+AS
+BEGIN
+    RETURN
+END
+GO
+
+DECLARE @x int = 1, @y int
+SELECT a, b
+FROM (VALUES (1, 2), (3, 4)) v (a, b)
+SELECT
+    a,
+    b, -- b
+    c
+FROM t
+ALTER TABLE t
+ADD a int, b int
+`
+	checkPrintConfig(t, &cfg, src, exp)
+}
+
+// Test for collapsing lists with comments directly before or after them.
+// Such lists aren't collapsed, so comments stay after the same words.
+func TestPrintCollapseComments(t *testing.T) {
+	src := `create procedure p -- proc comment
+	@a int -- param comment
+as return
+GO
+declare -- vars
+	@x int, @y int
+select a, b -- b
+from t`
+	cfg := DefaultConfig
+	cfg.CollapseLists = true
+	exp := `CREATE PROCEDURE p -- proc comment
+    @a int -- param comment
+AS
+RETURN
+GO
+
+DECLARE -- vars
+    @x int,
+    @y int
+SELECT
+    a,
+    b -- b
+FROM t
+`
+	checkPrintConfig(t, &cfg, src, exp)
+}
+
+// Test for wrapping expressions which don't fit into the line. Expressions are
+// broken before operators and after commas separating arguments.
+func TestPrintWrap(t *testing.T) {
+	src := `select coalesce(FirstName, SecondName, 'x') as Name, a from t
+	where LongColumnName = 1 and b between 1 and 10 and (c = 'x' or d = 'y')
+	if exists (select a, b from t where x = 1) and @LongVariable + @Other > 10 print 1
+	select a from t where x = 1 and y = 2`
+	cfg := DefaultConfig
+	cfg.LineWidth = 40
+	exp := `SELECT
+    COALESCE(
+        FirstName,
+        SecondName,
+        'x'
+    ) AS Name,
+    a
+FROM t
+WHERE LongColumnName = 1
+    AND b BETWEEN 1 AND 10
+    AND (c = 'x' OR d = 'y')
+IF EXISTS (SELECT a, b FROM t WHERE x = 1)
+    AND @LongVariable + @Other > 10
+    print 1
+SELECT a
+FROM t
+WHERE x = 1 AND y = 2
+`
+	checkPrintConfig(t, &cfg, src, exp)
+}
+
+// Test for aligning aliases of columns, SET statements and assignments of
+// UPDATE statement.
+func TestPrintAlign(t *testing.T) {
+	src := `select a as x, bbbb as yy, cc + 1 as z, d,
+	convert(varchar(100), SomeVeryLongColumnName, 112) as w, e as v from t
+	set @a = 1 set @longer += 2 print @a set @b = 2
+	update t set a = 1, bbb = 2 where c = 3
+	update t set a = 1`
+	exp := `SELECT
+    a      AS x,
+    bbbb   AS yy,
+    cc + 1 AS z,
+    d,
+    CONVERT(varchar(100), SomeVeryLongColumnName, 112) AS w,
+    e AS v
+FROM t
+SET @a      = 1
+SET @longer += 2
+print @a
+SET @b = 2
+UPDATE t
+SET
+    a   = 1,
+    bbb = 2
+WHERE c = 3
+UPDATE t
+SET a = 1
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for disabling alignment by zero threshold.
+func TestPrintAlignDisabled(t *testing.T) {
+	src := `select a as x, bbbb as yy from t
+	declare @a int, @bbb varchar(10) = 'x'`
+	cfg := DefaultConfig
+	cfg.AlignThreshold = 0
+	exp := `SELECT
+    a AS x,
+    bbbb AS yy
+FROM t
+DECLARE
+    @a int,
+    @bbb varchar(10) = 'x'
+`
+	checkPrintConfig(t, &cfg, src, exp)
+}
+
+// Test for adding optional keywords and semicolons.
+func TestPrintOptionalKeywords(t *testing.T) {
+	src := `select a x, b as y, cast(c as int) z, d from t
+	left join u as u on 1 = 1 join v on 1 = 1 inner hash join w on 1 = 1
+	;with cte as (select 1 a) select a from cte
+	if @a = 1 begin set @a = 2 end else throw 50000, 'x', 1`
+	cfg := DefaultConfig
+	cfg.AliasKeyword, cfg.OuterKeyword, cfg.InnerKeyword = ALWAYS, ALWAYS,
+		ALWAYS
+	cfg.Semicolons = true
+	exp := `SELECT
+    a              AS x,
+    b              AS y,
+    CAST(c AS int) AS z,
+    d
+FROM t
+LEFT OUTER JOIN u AS u
+    ON 1 = 1
+INNER JOIN v
+    ON 1 = 1
+INNER HASH JOIN w
+    ON 1 = 1;
+WITH cte AS (
+    SELECT 1 AS a
+)
+SELECT a
+FROM cte;
+IF @a = 1
+BEGIN
+    SET @a = 2;
+END
+ELSE
+    THROW 50000, 'x', 1;
+`
+	checkPrintConfig(t, &cfg, src, exp)
+}
+
+// Test for adding semicolons only after statements recognized by the
+// parser and after complete INSERT, DELETE and MERGE statements. Raw
+// statements are printed as they were written, in their source lines.
+func TestPrintSemicolonsRaw(t *testing.T) {
+	src := `ALTER INDEX ix ON t REBUILD
+	IF OBJECT_ID('t') IS NOT NULL DROP TABLE t
+	MERGE t USING s ON t.id = s.id WHEN MATCHED THEN UPDATE SET a = s.a
+	WHEN NOT MATCHED THEN DELETE
+	PRINT 1
+	INSERT INTO t (a) VALUES (1)
+	DELETE FROM t WHERE a = 1
+	INSERT INTO t DEFAULT VALUES`
+	cfg := DefaultConfig
+	cfg.Semicolons = true
+	exp := `ALTER INDEX ix ON t REBUILD
+IF OBJECT_ID('t') IS NOT NULL
+    DROP TABLE t;
+MERGE t USING s ON t.id = s.id WHEN MATCHED THEN UPDATE SET a = s.a
+    WHEN NOT MATCHED THEN DELETE;
+PRINT 1
+INSERT INTO t (a) VALUES (1);
+DELETE FROM t WHERE a = 1;
+INSERT INTO t DEFAULT VALUES
+`
+	checkPrintConfig(t, &cfg, src, exp)
+}
+
+// Test for printing raw statements in lines of the source. Following lines
+// are indented and a list of columns after the name of the table is
+// separated by a space.
+func TestPrintRawLines(t *testing.T) {
+	src := `if 1 = 1 insert into #tmp (id, val) -- target
+	select id, val from dbo.t
+	where a = 1
+	insert dbo.t(a) values (1), (2)`
+	exp := `IF 1 = 1
+    INSERT INTO #tmp (id, val) -- target
+        SELECT id, val FROM dbo.t
+        WHERE a = 1
+INSERT dbo.t (a) VALUES
+    (1),
+    (2)
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for removing optional keywords.
+func TestPrintNoOptionalKeywords(t *testing.T) {
+	src := `select a as x, cast(c as int) as z from t as t
+	left outer join u as u on 1 = 1 inner join v on 1 = 1
+	inner hash join w on 1 = 1`
+	cfg := DefaultConfig
+	cfg.AliasKeyword, cfg.OuterKeyword, cfg.InnerKeyword = NEVER, NEVER,
+		NEVER
+	exp := `SELECT
+    a x,
+    CAST(c AS int) z
+FROM t t
+LEFT JOIN u u
+    ON 1 = 1
+JOIN v
+    ON 1 = 1
+INNER HASH JOIN w
+    ON 1 = 1
+`
+	checkPrintConfig(t, &cfg, src, exp)
+}
+
+// Test for printing words which touched in the source. Binary and money
+// constants, compound assignments and unknown operators aren't split and
+// consecutive minus signs aren't joined into a comment.
+func TestPrintTouchingWords(t *testing.T) {
+	src := `select 0x01, $100, flags & 0xFF, a|b, ~c, - -1, a*-1
+	where flags&0xFF = 0x10
+	set @a += 1`
+	exp := `SELECT
+    0x01,
+    $100,
+    flags & 0xFF,
+    a|b,
+    ~c,
+    - -1,
+    a * -1
+WHERE flags&0xFF = 0x10
+SET @a += 1
+`
+	checkPrint(t, src, exp)
+}
+
+// Test for printing statements which start with a query or with a semicolon
+// before common table expressions.
+func TestPrintStatementStart(t *testing.T) {
+	src := `;with cte as (select 1 as n) select n from cte
+	insert into t (a) values (1)
+	select a from t
+	(select 2)
+	go
+	;with x as (select 1 as a) delete from x`
+	exp := `;WITH cte AS (
+    SELECT 1 AS n
+)
+SELECT n
+FROM cte
+INSERT INTO t (a) VALUES (1)
+SELECT a
+FROM t
+(SELECT 2)
+GO
+
+;WITH x AS (SELECT 1 AS a) DELETE FROM x
+`
+	checkPrint(t, src, exp)
+}
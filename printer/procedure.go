@@ -0,0 +1,111 @@
+package printer
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+	"strings"
+)
+
+// Method procedure prints CREATE/ALTER PROCEDURE statement. Each parameter is
+// printed in a separate line. Parameters names, types and default values are
+// aligned.
+func (p *printer) procedure(proc *ast.ProcedureStatement) {
+	p.createMode(proc.Mode)
+	if proc.Proc {
+		p.print(" ", p.keyword("PROC"))
+	} else {
+		p.print(" ", p.keyword("PROCEDURE"))
+	}
+	p.print(" ", proc.Name)
+
+	if proc.Parens {
+		p.parameters(proc.Parameters, " (", ")")
+	} else {
+		p.parameters(proc.Parameters, " ", "")
+	}
+
+	p.options(proc.Options)
+	if proc.ForReplication {
+		p.newline()
+		p.print(p.keyword("FOR REPLICATION"))
+	}
+	p.newline()
+	p.print(p.keyword("AS"))
+	if len(proc.Body) > 0 {
+		p.pendingBefore(proc.Body[0].Pos())
+		p.newline()
+		p.statementList(proc.Body)
+	}
+}
+
+// Method parameters prints procedure or function parameters surrounded by
+// open and close strings. Short lists are printed in the current line when
+// they are collapsed. Otherwise parameters are printed in separate lines with
+// one level of indentation, columns of parameters definitions are aligned and
+// parentheses are printed in separate lines.
+func (p *printer) parameters(params []*ast.Parameter, open, close string) {
+	rows := make([][]string, len(params))
+	positions := make([][]token.Position, len(params))
+	for id, param := range params {
+		positions[id] = append(p.detach(func() {
+			rows[id] = p.parameter(param)
+		}), param.End)
+	}
+	if p.config.CollapseLists &&
+		p.inline(joinCells(rows), positions, open, close) {
+		return
+	}
+
+	if open = strings.TrimSpace(open); open != "" {
+		p.newline()
+		p.print(open)
+	}
+	p.indent++
+	p.listLines(alignColumns(rows, p.config.AlignThreshold), positions)
+	p.indent--
+	if close != "" {
+		p.newline()
+		p.print(close)
+	}
+}
+
+// Method parameter returns cells of parameter definition - name, data type and
+// the rest (default value and options).
+func (p *printer) parameter(param *ast.Parameter) []string {
+	dataType := p.dataType(param.Type)
+	if param.ASKeyword {
+		dataType = p.keyword("AS") + " " + dataType
+	}
+	if param.Varying {
+		dataType += " " + p.keyword("VARYING")
+	}
+
+	rest := make([]string, 0, 3)
+	if param.Default != nil {
+		rest = append(rest, "= "+p.expr(param.Default))
+	}
+	if param.Output != "" {
+		rest = append(rest, p.keyword(param.Output))
+	}
+	if param.ReadOnly {
+		rest = append(rest, p.keyword("READONLY"))
+	}
+	return []string{param.Name, dataType, strings.Join(rest, " ")}
+}
+
+// Method options prints WITH clause of CREATE statements in a new line.
+func (p *printer) options(options []ast.Expression) {
+	if len(options) == 0 {
+		return
+	}
+
+	opts := make([]string, len(options))
+	positions := p.detach(func() {
+		for id, option := range options {
+			opts[id] = p.optionExpr(option)
+		}
+	})
+	p.newline()
+	p.print(p.keyword("WITH"), " ", strings.Join(opts, ", "))
+	p.replay(positions)
+}
@@ -0,0 +1,468 @@
+package printer
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+	"strings"
+	"unicode/utf8"
+)
+
+// Method selectStatement prints SELECT statement. Common table expressions
+// are printed before the first query and set operators are printed in
+// separate lines between queries.
+func (p *printer) selectStatement(stmt *ast.SelectStatement) {
+	p.commonTableExprs(stmt.With)
+	p.selectQuery(stmt.Query)
+
+	for _, setOp := range stmt.SetOps {
+		p.newline()
+		p.print(p.keyword(setOp.Operator.String()))
+		if setOp.All {
+			p.print(" ", p.keyword("ALL"))
+		}
+		p.newline()
+		p.selectQuery(setOp.Query)
+	}
+}
+
+// Method commonTableExprs prints WITH clause. Each common table expression
+// starts in a new line and its query is indented.
+func (p *printer) commonTableExprs(ctes []*ast.CommonTableExpr) {
+	for id, cte := range ctes {
+		if id == 0 {
+			p.print(p.keyword("WITH"), " ")
+		}
+		p.print(cte.Name)
+		if len(cte.Columns) > 0 {
+			p.print(" (", strings.Join(cte.Columns, ", "), ")")
+		}
+		p.print(" ", p.keyword("AS"), " (")
+		p.subquery(cte.Query)
+		p.print(")")
+		if id < len(ctes)-1 {
+			p.print(",")
+		}
+		p.newline()
+	}
+}
+
+// Method subquery prints SELECT statement in new lines with one more level of
+// indentation. After subquery a new line is started, so closing parenthesis
+// can be printed.
+func (p *printer) subquery(stmt *ast.SelectStatement) {
+	p.indent++
+	p.newline()
+	p.selectStatement(stmt)
+	p.indent--
+	p.newline()
+}
+
+// Method selectQuery prints single SELECT query. Each clause starts in a new
+// line.
+func (p *printer) selectQuery(query *ast.SelectQuery) {
+	p.print(p.keyword("SELECT"))
+	if query.DistinctType != nil {
+		if query.DistinctType.Distinct {
+			p.print(" ", p.keyword("DISTINCT"))
+		}
+		if query.DistinctType.All {
+			p.print(" ", p.keyword("ALL"))
+		}
+	}
+	if query.Top != nil {
+		p.top(query.Top)
+	}
+	p.columns(query.Columns)
+
+	if query.Into != nil {
+		p.newline()
+		p.print(p.keyword("INTO"), " ", *query.Into)
+	}
+	if query.From != nil {
+		p.from(query.From)
+	}
+	if query.Where != nil {
+		p.newline()
+		p.print(p.keyword("WHERE"), " ")
+		p.exprBlock(query.Where.Condition)
+	}
+	if query.GroupBy != nil {
+		p.newline()
+		p.print(p.keyword("GROUP BY"))
+		p.exprLines(query.GroupBy.Items)
+	}
+	if query.Having != nil {
+		p.newline()
+		p.print(p.keyword("HAVING"), " ")
+		p.exprBlock(query.Having.Condition)
+	}
+	if query.Window != nil {
+		p.window(query.Window)
+	}
+	if query.OrderBy != nil {
+		p.newline()
+		p.print(p.keyword("ORDER BY"))
+		p.exprLines(query.OrderBy.Items)
+		if query.OrderBy.Offset != nil {
+			p.newline()
+			p.print(p.expr(query.OrderBy.Offset))
+		}
+	}
+	if query.For != nil {
+		p.newline()
+		p.print(p.keyword("FOR"), " ", p.keyword(query.For.Mode))
+		directives := make([]string, len(query.For.Options))
+		for id, directive := range query.For.Options {
+			directives[id] = p.forDirective(directive)
+		}
+		if len(directives) > 0 {
+			p.print(" ", strings.Join(directives, ", "))
+		}
+	}
+	if query.Options != nil {
+		p.newline()
+		p.print(p.keyword("OPTION"), " (", p.optionList(query.Options.Hints), ")")
+	}
+}
+
+// Method forDirective returns single directive of FOR XML or FOR JSON clause.
+// Leading words of the directive (like PATH, ROOT or ELEMENTS XSINIL) are
+// keywords, arguments in parentheses are printed as they are.
+func (p *printer) forDirective(directive ast.Expression) string {
+	words := make(ast.Expression, len(directive))
+	copy(words, directive)
+	for id := 0; id < len(words) && words[id].Token == token.IDENT; id++ {
+		words[id].Literal = p.keyword(words[id].Literal)
+	}
+	return p.expr(words)
+}
+
+// Method top prints TOP clause of SELECT query.
+func (p *printer) top(top *ast.TopClause) {
+	p.print(" ", p.keyword("TOP"), " ", p.expr(top.Expr))
+	if top.PercentParam {
+		p.print(" ", p.keyword("PERCENT"))
+	}
+	if top.WithTiesParam {
+		p.print(" ", p.keyword("WITH TIES"))
+	}
+}
+
+// Method columns prints SELECT column list. Single column is printed in the
+// same line as SELECT keyword, as well as short lists when they are
+// collapsed. Otherwise each column is printed in a separate line with one
+// level of indentation.
+func (p *printer) columns(cols []ast.Expression) {
+	cols = p.columnAliases(cols)
+	if len(cols) == 1 {
+		p.print(" ")
+		p.exprBlock(cols[0])
+		return
+	}
+	if p.config.CollapseLists && !anyLongCase(cols) {
+		items, positions := p.exprItems(cols)
+		if p.inline(items, positions, " ", "") {
+			return
+		}
+	}
+
+	p.indent++
+	lines, positions := p.aliasLines(cols)
+	for id, col := range cols {
+		p.newline()
+		p.print(p.itemPrefix(id, len(cols)))
+		if lines[id] != "" {
+			p.print(lines[id])
+			p.replay(positions[id])
+		} else {
+			p.exprBlock(col)
+		}
+		p.print(p.itemSuffix(id, len(cols)))
+		p.passedItem(exprEnd(col), id < len(cols)-1)
+	}
+	p.keepIndent()
+	p.indent--
+}
+
+// Method aliasLines returns lines of SELECT columns with aliases given by AS
+// keyword together with positions of their words. Aliases of consecutive
+// columns are aligned. Lines of columns without alias, columns which don't
+// fit into their lines and columns with multi-line CASE expressions are
+// empty, they are printed by exprBlock method.
+func (p *printer) aliasLines(cols []ast.Expression) ([]string,
+	[][]token.Position) {
+	lines := make([]string, len(cols))
+	positions := make([][]token.Position, len(cols))
+	rows := make([][]string, 0, len(cols))
+	flush := func(end int) {
+		for id, line := range alignColumns(rows, p.config.AlignThreshold) {
+			lines[end-len(rows)+id] = line
+		}
+		rows = rows[:0]
+	}
+
+	for id, col := range cols {
+		as := len(col) - 2
+		if as < 1 || col[as].Token != token.AS || hasLongCase(col) {
+			flush(id)
+			continue
+		}
+		var row []string
+		positions[id] = p.detach(func() {
+			row = []string{p.expr(col[:as]), p.expr(col[as:])}
+		})
+		line := p.itemPrefix(id, len(cols)) + strings.Join(row, " ") +
+			p.itemSuffix(id, len(cols))
+		if p.indent*p.config.IndentWidth+utf8.RuneCountInString(line) >
+			p.config.LineWidth {
+			flush(id)
+			continue
+		}
+		rows = append(rows, row)
+	}
+	flush(len(cols))
+	return lines, positions
+}
+
+// Method columnAliases returns SELECT columns with AS keyword of aliases
+// added or removed as AliasKeyword says. Aliases are the last words of
+// columns recorded as aliases by the parser. Given columns aren't modified.
+func (p *printer) columnAliases(cols []ast.Expression) []ast.Expression {
+	if p.config.AliasKeyword == KEEP {
+		return cols
+	}
+	rewritten := make([]ast.Expression, len(cols))
+	for id, col := range cols {
+		rewritten[id] = col
+		alias := len(col) - 1
+		if alias < 1 || !p.aliases[col[alias].Pos.Offset] {
+			continue
+		}
+		as := col[alias-1].Token == token.AS
+		if optional(as, p.config.AliasKeyword) == as {
+			continue
+		}
+		expr := make(ast.Expression, 0, len(col)+1)
+		if as {
+			expr = append(append(expr, col[:alias-1]...), col[alias])
+		} else {
+			expr = append(append(expr, col[:alias]...), ast.Word{
+				Token: token.AS, Literal: p.keyword("AS")}, col[alias])
+		}
+		rewritten[id] = expr
+	}
+	return rewritten
+}
+
+// Method exprItems returns list items of given expressions and positions of
+// their words for inline and listLines methods.
+func (p *printer) exprItems(exprs []ast.Expression) ([]string,
+	[][]token.Position) {
+	items := make([]string, len(exprs))
+	positions := make([][]token.Position, len(exprs))
+	for id, expr := range exprs {
+		positions[id] = append(p.detach(func() {
+			items[id] = p.expr(expr)
+		}), exprEnd(expr))
+	}
+	return items, positions
+}
+
+// Method exprLines prints comma-separated expressions in the current line if
+// they fit into it. Otherwise each expression is printed in a separate line
+// with one level of indentation.
+func (p *printer) exprLines(exprs []ast.Expression) {
+	items, positions := p.exprItems(exprs)
+	if p.inline(items, positions, " ", "") {
+		return
+	}
+	p.indent++
+	p.listLines(items, positions)
+	p.indent--
+}
+
+// Method from prints FROM clause. Each JOIN starts in a new line and its
+// search condition is printed in the next line with one level of
+// indentation.
+func (p *printer) from(from *ast.FromClause) {
+	p.newline()
+	p.print(p.keyword("FROM"), " ")
+	p.tableName(from.TableOrViewName)
+
+	for _, join := range from.Joins {
+		if join.Type == ast.COMMA {
+			p.print(",")
+			p.indent++
+			p.newline()
+			p.tableName(&join.RightTableName)
+			p.indent--
+			continue
+		}
+
+		p.newline()
+		p.print(p.joinType(join), " ")
+		p.tableName(&join.RightTableName)
+		if join.Condition != nil {
+			p.indent++
+			p.newline()
+			p.print(p.keyword("ON"), " ")
+			p.exprBlock(join.Condition)
+			p.indent--
+		}
+	}
+}
+
+// Method joinType returns join type keywords together with join hint, like
+// "LEFT OUTER JOIN" or "INNER HASH JOIN". Optional INNER and OUTER keywords
+// are printed as InnerKeyword and OuterKeyword say, but INNER followed by join
+// hint is always kept.
+func (p *printer) joinType(join ast.SQLJoin) string {
+	words := make([]string, 0, 4)
+
+	switch join.Type {
+	case ast.INNER, ast.JOIN:
+		inner := join.Type == ast.INNER
+		if optional(inner, p.config.InnerKeyword) ||
+			inner && join.Hints.Hint != "" {
+			words = append(words, "INNER")
+		}
+	case ast.LEFT, ast.LEFTOUTER:
+		words = p.outerJoin(words, "LEFT", join.Type == ast.LEFTOUTER)
+	case ast.RIGHT, ast.RIGHTOUTER:
+		words = p.outerJoin(words, "RIGHT", join.Type == ast.RIGHTOUTER)
+	case ast.FULL, ast.FULLOUTER:
+		words = p.outerJoin(words, "FULL", join.Type == ast.FULLOUTER)
+	case ast.CROSS:
+		words = append(words, "CROSS")
+	case ast.CROSSAPPLY:
+		return p.keyword("CROSS APPLY")
+	case ast.OUTERAPPLY:
+		return p.keyword("OUTER APPLY")
+	}
+
+	if join.Hints.Hint != "" {
+		words = append(words, join.Hints.Hint)
+	}
+	words = append(words, "JOIN")
+	return p.keyword(strings.Join(words, " "))
+}
+
+// Method outerJoin appends type of outer join followed by OUTER keyword when
+// it's printed. Argument outer says if OUTER is written in the source.
+func (p *printer) outerJoin(words []string, joinType string,
+	outer bool) []string {
+	words = append(words, joinType)
+	if optional(outer, p.config.OuterKeyword) {
+		words = append(words, "OUTER")
+	}
+	return words
+}
+
+// Method tableName prints single table source of FROM clause with its alias,
+// sample clause and table hints. Derived tables are printed as indented
+// subqueries. PIVOT and UNPIVOT operators are printed in separate lines with
+// one level of indentation. Comments which follow the table source are
+// written at the end of its line.
+func (p *printer) tableName(table *ast.TableName) {
+	switch {
+	case table.Subquery != nil:
+		p.print("(")
+		p.subquery(table.Subquery)
+		p.print(")")
+	case table.Source != nil:
+		p.valuesBlock(table.Source)
+	default:
+		p.print(table.Name)
+		if table.Args != nil {
+			p.print("(", p.exprList(table.Args), ")")
+		}
+		if table.Schema != nil {
+			p.print(" ", p.keyword("WITH"), " (", p.exprList(table.Schema), ")")
+		}
+		if table.SystemTime != nil {
+			p.systemTime(table.SystemTime)
+		}
+	}
+
+	if table.Alias != nil {
+		if optional(table.ASKeyword, p.config.AliasKeyword) {
+			p.print(" ", p.keyword("AS"))
+		}
+		p.print(" ", *table.Alias)
+	}
+	if len(table.Columns) > 0 {
+		p.print(" (", strings.Join(table.Columns, ", "), ")")
+	}
+	if table.Sample != nil {
+		p.tableSample(table.Sample)
+	}
+	if table.Hints != nil {
+		p.print(" ", p.keyword("WITH"), " (", p.optionList(table.Hints.Hints), ")")
+	}
+
+	p.indent++
+	for _, pivot := range table.Pivots {
+		p.newline()
+		p.pivotClause(pivot)
+	}
+	p.indent--
+	p.passed(table.End)
+}
+
+// Method systemTime prints FOR SYSTEM_TIME clause of temporal table.
+func (p *printer) systemTime(clause *ast.SystemTimeClause) {
+	p.print(" ", p.keyword("FOR SYSTEM_TIME"), " ")
+	switch clause.Kind {
+	case ast.ASOF:
+		p.print(p.keyword("AS OF"), " ", p.expr(clause.Start))
+	case ast.FROMTO:
+		p.print(p.keyword("FROM"), " ", p.expr(clause.Start), " ",
+			p.keyword("TO"), " ", p.expr(clause.End))
+	case ast.BETWEENAND:
+		p.print(p.keyword("BETWEEN"), " ", p.expr(clause.Start), " ",
+			p.keyword("AND"), " ", p.expr(clause.End))
+	case ast.CONTAINEDIN:
+		p.print(p.keyword("CONTAINED IN"), " (", p.expr(clause.Start), ", ",
+			p.expr(clause.End), ")")
+	case ast.ALLTIME:
+		p.print(p.keyword("ALL"))
+	}
+}
+
+// Method pivotClause prints PIVOT or UNPIVOT operator with its alias in a
+// single line.
+func (p *printer) pivotClause(pivot *ast.PivotClause) {
+	operator := "PIVOT"
+	if pivot.Unpivot {
+		operator = "UNPIVOT"
+	}
+	p.print(p.keyword(operator), " (", p.expr(pivot.Value), " ",
+		p.keyword("FOR"), " ", pivot.For, " ", p.keyword("IN"),
+		" (", strings.Join(pivot.In, ", "), "))")
+
+	if pivot.Alias != "" {
+		if optional(pivot.ASKeyword, p.config.AliasKeyword) {
+			p.print(" ", p.keyword("AS"))
+		}
+		p.print(" ", pivot.Alias)
+	}
+}
+
+// Method tableSample prints TABLESAMPLE clause of table source.
+func (p *printer) tableSample(sample *ast.TableSampleClause) {
+	p.print(" ", p.keyword("TABLESAMPLE"))
+	if sample.System {
+		p.print(" ", p.keyword("SYSTEM"))
+	}
+	p.print(" (", p.expr(sample.SampleNumber))
+	if sample.Perc {
+		p.print(" ", p.keyword("PERCENT"))
+	}
+	if sample.Rows {
+		p.print(" ", p.keyword("ROWS"))
+	}
+	p.print(")")
+	if sample.Repeatable {
+		p.print(" ", p.keyword("REPEATABLE"), " (", p.expr(sample.RepSeed), ")")
+	}
+}
@@ -0,0 +1,212 @@
+package printer
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+	"strings"
+)
+
+// Method createTable prints CREATE TABLE statement. Table definition starts
+// in a new line and ON filegroup clause is printed after the definition.
+func (p *printer) createTable(table *ast.CreateTableStatement) {
+	p.print(p.keyword("CREATE TABLE"), " ", table.Name)
+	if table.Definition != nil {
+		p.tableDefinition(table.Definition)
+	}
+	if len(table.FileGroup) > 0 {
+		p.newline()
+		p.print(p.keyword("ON"), " ", p.expr(table.FileGroup))
+	}
+	if len(table.Options) > 0 {
+		p.newline()
+		p.print(p.expr(table.Options))
+	}
+}
+
+// Method tableDefinition prints columns and constraints of table definition
+// in parentheses. Short definitions are printed in the current line when lists
+// are collapsed. Otherwise parentheses and each element are printed in
+// separate lines. Names, data types and options of columns are aligned.
+func (p *printer) tableDefinition(definition *ast.TableDefinition) {
+	rows, positions := p.tableElements(definition.Elements)
+	if p.config.CollapseLists &&
+		p.inline(joinCells(rows), positions, " (", ")") {
+		return
+	}
+
+	p.newline()
+	p.print("(")
+	p.indent++
+	p.listLines(alignColumns(rows, p.config.AlignThreshold), positions)
+	p.indent--
+	p.newline()
+	p.print(")")
+}
+
+// Method tableElements returns cells of table definition elements and
+// positions of their words for listLines method.
+func (p *printer) tableElements(elements []ast.TableElement) ([][]string,
+	[][]token.Position) {
+	rows := make([][]string, len(elements))
+	positions := make([][]token.Position, len(elements))
+	for id, element := range elements {
+		positions[id] = append(p.detach(func() {
+			rows[id] = p.tableElement(element)
+		}), element.End())
+	}
+	return rows, positions
+}
+
+// Method tableElement returns cells of table definition element. Column
+// definition consists of name, data type and options. Computed columns,
+// constraints and other elements are kept in cells which don't affect
+// alignment of data types.
+func (p *printer) tableElement(element ast.TableElement) []string {
+	switch e := element.(type) {
+	case *ast.ColumnDefinition:
+		if e.Type == nil {
+			rest := p.columnOptions(e.Options)
+			if e.Computed != nil {
+				rest = strings.TrimSpace(p.keyword("AS") + " " +
+					p.expr(e.Computed) + " " + rest)
+			}
+			return []string{e.Name, rest}
+		}
+		return []string{e.Name, p.dataType(e.Type), p.columnOptions(e.Options)}
+	case *ast.Constraint:
+		return []string{p.constraint(e)}
+	case *ast.RawTableElement:
+		return []string{p.expr(e.Words)}
+	}
+	return nil
+}
+
+// Method columnOptions returns options of column definition as a single line
+// string.
+func (p *printer) columnOptions(options []*ast.ColumnOption) string {
+	opts := make([]string, len(options))
+	for id, option := range options {
+		if option.Kind == ast.CONSTRAINTOPTION {
+			opts[id] = p.constraint(option.Constraint)
+			continue
+		}
+		opts[id] = p.expr(option.Words)
+	}
+	return strings.Join(opts, " ")
+}
+
+// Method constraint returns column or table constraint as a single line
+// string.
+func (p *printer) constraint(constraint *ast.Constraint) string {
+	words := make([]string, 0, 8)
+	if constraint.Name != "" {
+		words = append(words, p.keyword("CONSTRAINT"), constraint.Name)
+	}
+
+	switch constraint.Kind {
+	case ast.PRIMARYKEY:
+		words = append(words, p.keyword("PRIMARY KEY"))
+	case ast.UNIQUE:
+		words = append(words, p.keyword("UNIQUE"))
+	case ast.FOREIGNKEY:
+		words = append(words, p.keyword("FOREIGN KEY"))
+	case ast.CHECK:
+		words = append(words, p.keyword("CHECK"))
+		if constraint.NotForReplication {
+			words = append(words, p.keyword("NOT FOR REPLICATION"))
+		}
+	case ast.DEFAULT:
+		words = append(words, p.keyword("DEFAULT"))
+	}
+
+	if constraint.Clustered != "" {
+		words = append(words, p.keyword(constraint.Clustered))
+	}
+	if constraint.Columns != nil {
+		words = append(words, "("+p.exprList(constraint.Columns)+")")
+	}
+	if constraint.References != "" {
+		words = append(words, p.keyword("REFERENCES"), constraint.References)
+		if constraint.RefColumns != nil {
+			words = append(words, "("+strings.Join(constraint.RefColumns, ", ")+")")
+		}
+	}
+	if len(constraint.Expr) > 0 {
+		words = append(words, p.expr(constraint.Expr))
+	}
+	if len(constraint.Options) > 0 {
+		words = append(words, p.expr(constraint.Options))
+	}
+	return strings.Join(words, " ")
+}
+
+// Method alterTable prints ALTER TABLE statement. Action is printed in a new
+// line. Multiple elements of ADD action are printed in separate lines with one
+// level of indentation and they are aligned as in table definition.
+func (p *printer) alterTable(alter *ast.AlterTableStatement) {
+	p.print(p.keyword("ALTER TABLE"), " ", alter.Name)
+	if alter.With != "" {
+		p.print(" ", p.keyword("WITH"), " ", p.keyword(alter.With))
+	}
+	p.newline()
+
+	switch alter.Action {
+	case ast.ADDACTION:
+		p.print(p.keyword("ADD"))
+		p.alterTableElements(alter.Elements)
+	case ast.ALTERCOLUMNACTION:
+		rows := [][]string{p.tableElement(alter.Column)}
+		p.print(p.keyword("ALTER COLUMN"), " ", joinCells(rows)[0])
+	case ast.DROPACTION:
+		items := make([]string, len(alter.Drops))
+		for id, item := range alter.Drops {
+			items[id] = p.dropItem(item)
+		}
+		p.print(p.keyword("DROP"), " ", strings.Join(items, ", "))
+	case ast.CHECKACTION, ast.NOCHECKACTION:
+		action := "CHECK CONSTRAINT"
+		if alter.Action == ast.NOCHECKACTION {
+			action = "NOCHECK CONSTRAINT"
+		}
+		p.print(p.keyword(action), " ", strings.Join(alter.Constraints, ", "))
+	case ast.OTHERACTION:
+		p.print(p.expr(alter.Words))
+	}
+}
+
+// Method alterTableElements prints elements of ALTER TABLE ... ADD statement.
+// Single element is printed in the current line, as well as short lists when
+// they are collapsed.
+func (p *printer) alterTableElements(elements []ast.TableElement) {
+	rows, positions := p.tableElements(elements)
+	if len(rows) == 1 {
+		p.print(" ", joinCells(rows)[0])
+		p.replay(positions[0])
+		return
+	}
+	if p.config.CollapseLists &&
+		p.inline(joinCells(rows), positions, " ", "") {
+		return
+	}
+
+	p.indent++
+	p.listLines(alignColumns(rows, p.config.AlignThreshold), positions)
+	p.indent--
+}
+
+// Method dropItem returns single item of ALTER TABLE ... DROP statement as a
+// string.
+func (p *printer) dropItem(item *ast.DropItem) string {
+	words := make([]string, 0, 4)
+	if item.Kind != "" {
+		words = append(words, p.keyword(item.Kind))
+	}
+	if item.IfExists {
+		words = append(words, p.keyword("IF EXISTS"))
+	}
+	words = append(words, item.Name)
+	if len(item.Options) > 0 {
+		words = append(words, p.expr(item.Options))
+	}
+	return strings.Join(words, " ")
+}
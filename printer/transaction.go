@@ -0,0 +1,91 @@
+package printer
+
+import (
+	"mssfmt/ast"
+	"strings"
+)
+
+// Method tryCatchStatement prints TRY ... CATCH construct. Statements of both
+// blocks are indented.
+func (p *printer) tryCatchStatement(stmt *ast.TryCatchStatement) {
+	p.print(p.keyword("BEGIN TRY"))
+	p.indentedList(stmt.Try)
+	p.newline()
+	p.print(p.keyword("END TRY"))
+	p.newline()
+	p.print(p.keyword("BEGIN CATCH"))
+	p.indentedList(stmt.Catch)
+	p.newline()
+	p.print(p.keyword("END CATCH"))
+}
+
+// Method indentedList prints statements in new lines with one more level of
+// indentation.
+func (p *printer) indentedList(stmts []ast.Statement) {
+	if len(stmts) == 0 {
+		return
+	}
+	p.pendingBefore(stmts[0].Pos())
+	p.indent++
+	p.newline()
+	p.statementList(stmts)
+	p.indent--
+}
+
+// Method transactionStatement prints BEGIN, COMMIT, ROLLBACK and SAVE
+// TRANSACTION statements in a single line.
+func (p *printer) transactionStatement(stmt *ast.TransactionStatement) {
+	words := make([]string, 0, 6)
+	switch stmt.Kind {
+	case ast.BEGINTRAN:
+		words = append(words, p.keyword("BEGIN"))
+	case ast.COMMITTRAN:
+		words = append(words, p.keyword("COMMIT"))
+	case ast.ROLLBACKTRAN:
+		words = append(words, p.keyword("ROLLBACK"))
+	case ast.SAVETRAN:
+		words = append(words, p.keyword("SAVE"))
+	}
+	if stmt.Distributed {
+		words = append(words, p.keyword("DISTRIBUTED"))
+	}
+	if stmt.Keyword != "" {
+		words = append(words, p.keyword(stmt.Keyword))
+	}
+	if stmt.Name != "" {
+		words = append(words, stmt.Name)
+	}
+	if stmt.Mark {
+		words = append(words, p.keyword("WITH MARK"))
+		if stmt.Description != "" {
+			words = append(words, stmt.Description)
+		}
+	}
+	if len(stmt.Options) > 0 {
+		words = append(words, p.expr(stmt.Options))
+	}
+	p.print(strings.Join(words, " "))
+}
+
+// Method throwStatement prints THROW statement with optional arguments.
+func (p *printer) throwStatement(stmt *ast.ThrowStatement) {
+	p.print(p.keyword("THROW"))
+	if len(stmt.Args) > 0 {
+		p.print(" ", p.exprList(stmt.Args))
+	}
+}
+
+// Method raiserrorStatement prints RAISERROR statement. Options are
+// uppercased.
+func (p *printer) raiserrorStatement(stmt *ast.RaiserrorStatement) {
+	p.print(p.keyword("RAISERROR"), "(", p.exprList(stmt.Args), ")")
+	if len(stmt.Options) == 0 {
+		return
+	}
+
+	opts := make([]string, len(stmt.Options))
+	for id, option := range stmt.Options {
+		opts[id] = p.keyword(option)
+	}
+	p.print(" ", p.keyword("WITH"), " ", strings.Join(opts, ", "))
+}
@@ -0,0 +1,50 @@
+package printer
+
+import (
+	"mssfmt/ast"
+	"strings"
+)
+
+// Method trigger prints CREATE/ALTER TRIGGER statement. Options, trigger
+// events and NOT FOR REPLICATION are printed in separate lines.
+func (p *printer) trigger(trigger *ast.TriggerStatement) {
+	p.createMode(trigger.Mode)
+	p.print(" ", p.keyword("TRIGGER"), " ", trigger.Name, " ", p.keyword("ON"))
+	if trigger.AllServer {
+		p.print(" ", p.keyword("ALL SERVER"))
+	} else {
+		p.print(" ", trigger.Target)
+	}
+
+	p.options(trigger.Options)
+	p.newline()
+	switch trigger.Timing {
+	case ast.FOR:
+		p.print(p.keyword("FOR"))
+	case ast.AFTER:
+		p.print(p.keyword("AFTER"))
+	case ast.INSTEADOF:
+		p.print(p.keyword("INSTEAD OF"))
+	}
+	events := make([]string, len(trigger.Events))
+	for id, event := range trigger.Events {
+		events[id] = p.word(event)
+	}
+	p.print(" ", strings.Join(events, ", "))
+
+	if trigger.Append {
+		p.newline()
+		p.print(p.keyword("WITH APPEND"))
+	}
+	if trigger.NotForReplication {
+		p.newline()
+		p.print(p.keyword("NOT FOR REPLICATION"))
+	}
+	p.newline()
+	p.print(p.keyword("AS"))
+	if len(trigger.Body) > 0 {
+		p.pendingBefore(trigger.Body[0].Pos())
+		p.newline()
+		p.statementList(trigger.Body)
+	}
+}
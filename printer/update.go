@@ -0,0 +1,93 @@
+package printer
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+)
+
+// Method updateStatement prints UPDATE statement. Each clause starts in a new
+// line like in SELECT query.
+func (p *printer) updateStatement(stmt *ast.UpdateStatement) {
+	p.print(p.keyword("UPDATE"), " ", p.expr(stmt.Target))
+	p.newline()
+	p.print(p.keyword("SET"))
+	p.assignments(stmt.Assignments)
+
+	if stmt.Output != nil {
+		p.newline()
+		p.print(p.keyword("OUTPUT"), " ")
+		p.exprBlock(stmt.Output)
+	}
+	if stmt.From != nil {
+		p.from(stmt.From)
+	}
+	if stmt.Where != nil {
+		p.newline()
+		p.print(p.keyword("WHERE"), " ")
+		p.exprBlock(stmt.Where.Condition)
+	}
+	if stmt.Options != nil {
+		p.newline()
+		p.print(p.keyword("OPTION"), " (", p.optionList(stmt.Options.Hints), ")")
+	}
+}
+
+// Method assignments prints items of SET clause of UPDATE statement. Single
+// item is printed in the same line as SET keyword, as well as short lists when
+// they are collapsed. Otherwise each item is printed in a separate line with
+// one level of indentation and targets of consecutive items are aligned, so
+// their operators start in the same column.
+func (p *printer) assignments(items []*ast.Assignment) {
+	rows := make([][]string, len(items))
+	values := make([]ast.Expression, len(items))
+	positions := make([][]token.Position, len(items))
+	for id, item := range items {
+		positions[id] = p.detach(func() {
+			rows[id] = []string{p.expr(item.Target), item.Operator}
+		})
+		values[id] = item.Value
+	}
+
+	if len(items) == 1 {
+		p.print(" ")
+		p.assignment(joinCells(rows)[0], positions[0], values[0])
+		return
+	}
+	if p.config.CollapseLists && !anyLongCase(values) {
+		lines := make([]string, len(items))
+		inlinePositions := make([][]token.Position, len(items))
+		for id, item := range items {
+			lines[id] = joinCells(rows[id : id+1])[0]
+			inlinePositions[id] = append(p.detach(func() {
+				if item.Value != nil {
+					lines[id] += " " + p.expr(item.Value)
+				}
+			}), item.End)
+			inlinePositions[id] = append(positions[id], inlinePositions[id]...)
+		}
+		if p.inline(lines, inlinePositions, " ", "") {
+			return
+		}
+	}
+
+	p.indent++
+	for id, head := range alignColumns(rows, p.config.AlignThreshold) {
+		p.newline()
+		p.print(p.itemPrefix(id, len(items)))
+		p.assignment(head, positions[id], values[id])
+		p.print(p.itemSuffix(id, len(items)))
+	}
+	p.indent--
+}
+
+// Method assignment prints single item of SET clause. Head is the target
+// followed by the operator and positions are positions of words of the target.
+func (p *printer) assignment(head string, positions []token.Position,
+	value ast.Expression) {
+	p.print(head)
+	p.replay(positions)
+	if value != nil {
+		p.print(" ")
+		p.exprBlock(value)
+	}
+}
@@ -0,0 +1,160 @@
+package printer
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+	"strings"
+	"unicode/utf8"
+)
+
+// Method declareStatement prints DECLARE statement. Single variable is printed
+// in the same line as DECLARE, as well as short lists of scalar variables when
+// they are collapsed. Otherwise variables are printed in separate lines with
+// one level of indentation.
+func (p *printer) declareStatement(stmt *ast.DeclareStatement) {
+	p.print(p.keyword("DECLARE"))
+	if len(stmt.Variables) == 1 {
+		p.print(" ")
+		p.variables(stmt.Variables)
+		return
+	}
+	if p.config.CollapseLists && p.inlineVariables(stmt.Variables) {
+		return
+	}
+
+	p.indent++
+	p.newline()
+	p.variables(stmt.Variables)
+	p.indent--
+}
+
+// Method variables prints comma-separated variable declarations in separate
+// lines. Names, data types and values of variables are aligned, table
+// variables are followed by their table definition. Values which don't fit
+// into their lines or contain multi-line CASE expressions aren't aligned,
+// they are printed by exprBlock.
+func (p *printer) variables(vars []*ast.VariableDeclaration) {
+	lines := make([]string, len(vars))
+	positions := make([][]token.Position, len(vars))
+	rows := make([][]string, 0, len(vars))
+	flush := func(end int) {
+		for id, line := range alignColumns(rows, p.config.AlignThreshold) {
+			lines[end-len(rows)+id] = line
+		}
+		rows = rows[:0]
+	}
+	for id, variable := range vars {
+		var row []string
+		positions[id] = p.detach(func() {
+			row = p.variable(variable)
+		})
+		line := p.itemPrefix(id, len(vars)) + strings.Join(row, " ") +
+			p.itemSuffix(id, len(vars))
+		if variable.Value != nil && (hasLongCase(variable.Value) ||
+			p.width()+utf8.RuneCountInString(line) > p.config.LineWidth) {
+			flush(id)
+			continue
+		}
+		rows = append(rows, row)
+	}
+	flush(len(vars))
+
+	for id, line := range lines {
+		if id > 0 {
+			p.newline()
+		}
+		p.print(p.itemPrefix(id, len(vars)))
+		if line == "" {
+			p.variableBlock(vars[id])
+		} else {
+			p.print(line)
+			p.replay(positions[id])
+		}
+		if vars[id].Table != nil {
+			p.tableDefinition(vars[id].Table)
+		}
+		p.print(p.itemSuffix(id, len(vars)))
+		p.passedItem(vars[id].End, id < len(vars)-1)
+	}
+}
+
+// Method variableBlock prints declaration of scalar variable with its value
+// printed by exprBlock.
+func (p *printer) variableBlock(variable *ast.VariableDeclaration) {
+	var head []string
+	positions := p.detach(func() { head = p.variableHead(variable) })
+	p.print(strings.Join(head, " "), " = ")
+	p.replay(positions)
+	p.exprBlock(variable.Value)
+}
+
+// Method inlineVariables prints declarations of scalar variables in the
+// current line if they fit into it. It returns false if nothing was printed.
+func (p *printer) inlineVariables(vars []*ast.VariableDeclaration) bool {
+	rows := make([][]string, len(vars))
+	positions := make([][]token.Position, len(vars))
+	for id, variable := range vars {
+		if variable.Table != nil {
+			return false
+		}
+		positions[id] = append(p.detach(func() {
+			rows[id] = p.variable(variable)
+		}), variable.End)
+	}
+	return p.inline(joinCells(rows), positions, " ", "")
+}
+
+// Method variable returns cells of variable declaration - name, data type
+// and value. Table variables have TABLE keyword in place of data type and
+// their table definition is printed separately.
+func (p *printer) variable(variable *ast.VariableDeclaration) []string {
+	head := p.variableHead(variable)
+	if variable.Table != nil {
+		return head
+	}
+	value := ""
+	if variable.Value != nil {
+		value = "= " + p.expr(variable.Value)
+	}
+	return append(head, value)
+}
+
+// Method variableHead returns name and data type of variable declaration.
+func (p *printer) variableHead(variable *ast.VariableDeclaration) []string {
+	dataType := p.keyword("TABLE")
+	if variable.Table == nil {
+		dataType = p.dataType(variable.Type)
+	}
+	if variable.ASKeyword {
+		dataType = p.keyword("AS") + " " + dataType
+	}
+	return []string{variable.Name, dataType}
+}
+
+// Method setStatement prints assignment of a value to a variable. Variable is
+// padded as setHead says, when the statement is aligned with its neighbours.
+func (p *printer) setStatement(stmt *ast.SetStatement) {
+	head := p.setHead
+	if head == "" {
+		head = stmt.Variable + " " + stmt.Operator
+	}
+	p.setHead = ""
+	p.print(p.keyword("SET"), " ", head, " ")
+	p.exprBlock(stmt.Value)
+}
+
+// Method setOptionStatement prints SET statement of session options. Names of
+// options and isolation levels of SET TRANSACTION are printed in KeywordCase.
+func (p *printer) setOptionStatement(stmt *ast.SetOptionStatement) {
+	opts := make([]string, len(stmt.Options))
+	for id, option := range stmt.Options {
+		opts[id] = p.keyword(option)
+	}
+	p.print(p.keyword("SET"), " ", strings.Join(opts, ", "))
+	switch {
+	case len(stmt.Value) > 0 && strings.EqualFold(stmt.Options[0], "TRANSACTION"):
+		p.print(" ", p.optionExpr(stmt.Value))
+	case len(stmt.Value) > 0:
+		p.print(" ", p.expr(stmt.Value))
+	}
+}
@@ -0,0 +1,28 @@
+package printer
+
+import (
+	"mssfmt/ast"
+	"strings"
+)
+
+// Method view prints CREATE/ALTER VIEW statement. View query starts in a new
+// line after AS keyword.
+func (p *printer) view(view *ast.ViewStatement) {
+	p.createMode(view.Mode)
+	p.print(" ", p.keyword("VIEW"), " ", view.Name)
+	if len(view.Columns) > 0 {
+		p.print(" (", strings.Join(view.Columns, ", "), ")")
+	}
+
+	p.options(view.Options)
+	p.newline()
+	p.print(p.keyword("AS"))
+	if view.Query != nil {
+		p.newline()
+		p.selectStatement(view.Query)
+	}
+	if view.CheckOption {
+		p.newline()
+		p.print(p.keyword("WITH CHECK OPTION"))
+	}
+}
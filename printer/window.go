@@ -0,0 +1,95 @@
+package printer
+
+import (
+	"mssfmt/ast"
+	"mssfmt/token"
+	"strings"
+)
+
+// Method window prints WINDOW clause of SELECT query. Single window is
+// printed in the same line as WINDOW keyword, otherwise each window is printed
+// in a separate line with one level of indentation.
+func (p *printer) window(windows []*ast.WindowDefinition) {
+	p.newline()
+	p.print(p.keyword("WINDOW"))
+	if len(windows) == 1 {
+		p.print(" ", p.windowDefinition(windows[0]))
+		return
+	}
+
+	p.indent++
+	for id, window := range windows {
+		p.newline()
+		p.print(p.windowDefinition(window))
+		if id < len(windows)-1 {
+			p.print(",")
+		}
+	}
+	p.indent--
+}
+
+// Method windowDefinition returns named window definition as a single line
+// string.
+func (p *printer) windowDefinition(window *ast.WindowDefinition) string {
+	return window.Name + " " + p.keyword("AS") + " (" +
+		p.windowSpec(window.Spec) + ")"
+}
+
+// Method windowSpec returns window specification without parentheses as a
+// single line string.
+func (p *printer) windowSpec(spec *ast.WindowSpec) string {
+	parts, positions := p.windowParts(spec)
+	for _, partPositions := range positions {
+		p.replay(partPositions)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Method windowParts returns parts of window specification - window name,
+// PARTITION BY, ORDER BY and ROWS or RANGE clauses - as single line strings
+// together with positions of their words.
+func (p *printer) windowParts(spec *ast.WindowSpec) ([]string,
+	[][]token.Position) {
+	parts := make([]string, 0, 4)
+	positions := make([][]token.Position, 0, 4)
+	add := func(build func() string) {
+		var part string
+		positions = append(positions, p.detach(func() { part = build() }))
+		parts = append(parts, part)
+	}
+	if spec.Name != "" {
+		add(func() string { return spec.Name })
+	}
+	if spec.PartitionBy != nil {
+		add(func() string {
+			return p.keyword("PARTITION BY") + " " + p.exprList(spec.PartitionBy)
+		})
+	}
+	if spec.OrderBy != nil {
+		add(func() string {
+			return p.keyword("ORDER BY") + " " + p.exprList(spec.OrderBy)
+		})
+	}
+	if len(spec.Frame) > 0 {
+		add(func() string { return p.optionExpr(spec.Frame) })
+	}
+	return parts, positions
+}
+
+// Method overClause prints OVER clause with parts of its window specification
+// (see windowParts) in separate lines with one more level of indentation.
+// Argument over starts with OVER keyword, which keeps the specification, and
+// ends with closing parenthesis.
+func (p *printer) overClause(over ast.Expression) {
+	p.print(p.expr(over[:2]))
+	parts, positions := p.windowParts(over[0].Window)
+	p.indent++
+	for id, part := range parts {
+		p.newline()
+		p.print(part)
+		p.replay(positions[id])
+	}
+	p.indent--
+	p.newline()
+	p.print(p.word(over[len(over)-1]))
+}
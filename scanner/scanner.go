@@ -12,17 +12,22 @@ const singleQuote = 39 // value for single quote character
 const doubleQuote = 34 // value for double quote character
 const hashSign = 35    // value for '#' sign
 const atSign = 64      // value for '@' sign
+const dollarSign = 36  // value for '$' sign
 
 // Scanner represents current state of scanning .sql file char by char. In
 // source filed SQL script content is stored as slice of bytes. Field char
 // contains current character, offset is number of character in the file and
-// rdOffset is position after current offset.
+// rdOffset is position after current offset. Fields line and lineOffset
+// describe the current line and pos is position of the last scanned token.
 type Scanner struct {
-	fileName string
-	source   []byte // source content that is being scanned
-	char     rune   // current character
-	offset   int    // character offset
-	rdOffset int    // reading offset - position after current char
+	fileName   string
+	source     []byte // source content that is being scanned
+	char       rune   // current character
+	offset     int    // character offset
+	rdOffset   int    // reading offset - position after current char
+	line       int    // current line, starting at 1
+	lineOffset int    // offset of the current line start
+	pos        token.Position
 }
 
 // Scan method scans T-SQL script and returns T-SQL tokens defined in token
@@ -32,9 +37,17 @@ func (s *Scanner) Scan() (token.Token, string) {
 	var tok token.Token
 	var literal string
 	s.skipWhitespace()
+	s.pos = token.Position{
+		FileName: s.fileName,
+		Line:     s.line,
+		Column:   utf8.RuneCount(s.source[s.lineOffset:s.offset]) + 1,
+	}
 
 	switch ch := s.char; {
-	case isLetter(ch) || ch == hashSign || ch == atSign:
+	case (ch == 'N' || ch == 'n') && s.peek() == singleQuote:
+		return token.STRING, s.scanUnicodeString()
+	case isLetter(ch) || ch == hashSign || ch == atSign || ch == '[' ||
+		ch == doubleQuote:
 		literal = s.scanIdentifier()
 		ucLit := strings.ToUpper(literal)
 		if len(literal) > 1 {
@@ -48,20 +61,26 @@ func (s *Scanner) Scan() (token.Token, string) {
 			return token.IDENT, literal
 		}
 
-	case isDigit(ch) || (ch == '.' && isDigit(rune(s.peek()))) ||
-		((ch == '-' || ch == '+') && isDigit(rune(s.peek()))):
+	case isDigit(ch) || (ch == '.' && isDigit(rune(s.peek()))):
 		return s.scanNumber()
+	case ch == dollarSign && (isDecimal(rune(s.peek())) || s.peek() == '.'):
+		return s.scanMoney()
+	case ch == dollarSign && isLetter(rune(s.peek())):
+		s.next()
+		return token.IDENT, "$" + s.scanIdentifier()
 	case ch == '-' && s.peek() == '-':
 		return token.COMMENT, s.scanLineComment()
 	case ch == '/' && s.peek() == '*':
 		return token.COMMENT, s.scanBlockComment()
+	case ch == singleQuote:
+		return token.STRING, s.scanSQLString()
+	case ch == '<' || ch == '>' || ch == '!':
+		return s.scanComparison()
 	default:
 		s.next()
 		switch ch {
 		case -1:
 			return token.EOF, ""
-		case singleQuote:
-			return token.STRING, s.scanSQLString()
 		case '+':
 			return token.ADD, "+"
 		case '-':
@@ -80,20 +99,24 @@ func (s *Scanner) Scan() (token.Token, string) {
 			return token.COMMA, ","
 		case ';':
 			return token.SEMICOLON, ";"
+		case ':':
+			return token.COLON, ":"
 		case '(':
 			return token.LPAREN, "("
 		case ')':
 			return token.RPAREN, ")"
 		default:
-			return token.ILLEGAL, ""
+			return token.ILLEGAL, string(ch)
 		}
 	}
-	return token.ILLEGAL, ""
 }
 
 // Method handleMultiwordKeyword scans the rest (after first word) part of
-// multi-word keyword.
+// multi-word keyword. If the words don't form a keyword, only the first word
+// is returned.
 func (s *Scanner) handleMultiwordKeyword(firstWord string, nWords int) (token.Token, string) {
+	offset, rdOffset, char := s.offset, s.rdOffset, s.char
+	line, lineOffset := s.line, s.lineOffset
 	words := make([]string, nWords+1)
 	words[0] = firstWord
 
@@ -105,6 +128,13 @@ func (s *Scanner) handleMultiwordKeyword(firstWord string, nWords int) (token.To
 
 	keyword := strings.Join(words, " ")
 	tok := token.KeywordLookup(strings.ToUpper(keyword))
+	if tok == token.IDENT {
+		// First word isn't a part of multi-word keyword, like GROUP in
+		// WITHIN GROUP, so scanning is resumed after the first word.
+		s.offset, s.rdOffset, s.char = offset, rdOffset, char
+		s.line, s.lineOffset = line, lineOffset
+		return token.KeywordLookup(strings.ToUpper(firstWord)), firstWord
+	}
 	return tok, keyword
 }
 
@@ -116,6 +146,8 @@ func (s *Scanner) Init(fName string, src []byte) {
 	s.char = ' '
 	s.offset = 0
 	s.rdOffset = 0
+	s.line = 1
+	s.lineOffset = 0
 
 	s.next()
 	if s.char == bom {
@@ -123,9 +155,19 @@ func (s *Scanner) Init(fName string, src []byte) {
 	}
 }
 
+// Method Pos returns position of the last scanned token. Offset of the
+// position isn't set, because Scanner doesn't count scanned words.
+func (s *Scanner) Pos() token.Position {
+	return s.pos
+}
+
 // Method next reads next Unicode character into s.char. Case when s.char < 0
 // means EOF.
 func (s *Scanner) next() {
+	if s.char == '\n' {
+		s.line++
+		s.lineOffset = s.rdOffset
+	}
 	if s.rdOffset < len(s.source) {
 		s.offset = s.rdOffset
 		r := rune(s.source[s.rdOffset])
@@ -177,19 +219,13 @@ func (s *Scanner) peek() byte {
 func (s *Scanner) scanIdentifier() string {
 	startOffset := s.offset
 	if s.char == '[' {
-		for s.char != ']' {
-			s.next()
-		}
-		s.next()
+		s.scanDelimited(']')
 		return string(s.source[startOffset:s.offset])
 	}
 
 	if s.char == doubleQuote {
 		s.next()
-		for s.char != doubleQuote {
-			s.next()
-		}
-		s.next()
+		s.scanDelimited(doubleQuote)
 		return string(s.source[startOffset:s.offset])
 	}
 
@@ -202,38 +238,114 @@ func (s *Scanner) scanIdentifier() string {
 	return ""
 }
 
+// Method scanDelimited scans delimited identifier till given closing
+// delimiter (inclusive). Doubled closing delimiter, like "]]" in [a]]b], is
+// an escaped character of the identifier.
+func (s *Scanner) scanDelimited(closing rune) {
+	for s.char >= 0 {
+		if s.char == closing && s.peek() == byte(closing) {
+			s.next()
+			s.next()
+			continue
+		}
+		if s.char == closing {
+			s.next()
+			return
+		}
+		s.next()
+	}
+}
+
 // Method scanSQLString scans T-SQL string literal. Result also includes opening
 // and closing single quote - '. It also includes single quote escapement which
-// in T-SQL occurs as double single quote - ''. This metod assumes that the
-// opening single quote was already scanned.
+// in T-SQL occurs as doubled single quote. This method assumes that s.char
+// is the opening single quote.
 func (s *Scanner) scanSQLString() string {
 	startOffset := s.offset
+	s.next()
 
-	for {
-		s.next()
+	for s.char >= 0 {
 		if s.isEscapedSQ() {
 			s.next()
 			s.next()
+			continue
 		}
-		if s.char == singleQuote && !s.isEscapedSQ() {
+		if s.char == singleQuote {
 			s.next()
 			break
 		}
+		s.next()
 	}
 	return string(s.source[startOffset:s.offset])
 }
 
+// Method scanUnicodeString scans Unicode string literal - N'Value'. Result
+// includes N prefix. This method assumes that s.char is N prefix.
+func (s *Scanner) scanUnicodeString() string {
+	startOffset := s.offset
+	s.next()
+	s.scanSQLString()
+	return string(s.source[startOffset:s.offset])
+}
+
+// Method scanComparison scans comparison operators which starts from '<', '>'
+// or '!' character. Both "!=" and "<>" are scanned as token.NEQ.
+func (s *Scanner) scanComparison() (token.Token, string) {
+	ch := s.char
+	s.next()
+	next := s.char
+
+	switch {
+	case ch == '<' && next == '=':
+		s.next()
+		return token.LEQ, "<="
+	case ch == '<' && next == '>':
+		s.next()
+		return token.NEQ, "<>"
+	case ch == '>' && next == '=':
+		s.next()
+		return token.GEQ, ">="
+	case ch == '!' && next == '=':
+		s.next()
+		return token.NEQ, "!="
+	case ch == '!' && next == '<':
+		s.next()
+		return token.GEQ, "!<"
+	case ch == '!' && next == '>':
+		s.next()
+		return token.LEQ, "!>"
+	case ch == '<':
+		return token.LSS, "<"
+	case ch == '>':
+		return token.GTR, ">"
+	}
+	return token.ILLEGAL, string(ch)
+}
+
 // Method scanNumber scans number literals. It includes integers, floats and
-// decimals, scientific notation and hexidecimal format (TODO). This method
-// assumes that s.char is a digit.
+// decimals, scientific notation and hexadecimal binary constants like 0x1F.
+// This method assumes that s.char is a digit or a sign. Scan never passes the
+// sign here, it's scanned as a separate operator, so "@a-1" isn't scanned as
+// "@a" and "-1".
 func (s *Scanner) scanNumber() (token.Token, string) {
 	startOffset := s.offset
 	var tok token.Token = token.INT
+	if s.char == '.' {
+		tok = token.FLOAT
+	}
+
+	if s.char == '0' && (s.peek() == 'x' || s.peek() == 'X') {
+		s.next()
+		for s.next(); isHex(s.char); s.next() {
+		}
+		return tok, string(s.source[startOffset:s.offset])
+	}
 
 	for {
 		s.next()
+		exponent := s.source[s.offset-1] == 'e' || s.source[s.offset-1] == 'E'
 		if !isDigit(s.char) && s.char != '.' && s.char != 'e' &&
-			s.char != 'E' && s.char != '+' && s.char != '-' {
+			s.char != 'E' && !((s.char == '+' || s.char == '-') && exponent) {
 			break
 		}
 
@@ -244,13 +356,22 @@ func (s *Scanner) scanNumber() (token.Token, string) {
 	return tok, string(s.source[startOffset:s.offset])
 }
 
+// Method scanMoney scans money constants, which are numbers prefixed with
+// '$' sign, like $12.50. This method assumes that s.char is the '$' sign.
+func (s *Scanner) scanMoney() (token.Token, string) {
+	startOffset := s.offset
+	s.next()
+	tok, _ := s.scanNumber()
+	return tok, string(s.source[startOffset:s.offset])
+}
+
 // Method scanLineComment scans line comment in T-SQL which starts from "--" and
 // ends at line break. This method assumes that s.char == '-' and s.peek() ==
 // '-', so it's a line comment start.
 func (s *Scanner) scanLineComment() string {
 	startOffset := s.offset
 
-	for s.char != '\n' && s.char != '\r' {
+	for s.char != '\n' && s.char != '\r' && s.char >= 0 {
 		s.next()
 	}
 	return string(s.source[startOffset:s.offset])
@@ -264,7 +385,7 @@ func (s *Scanner) scanBlockComment() string {
 	startOffset := s.offset
 	nestingLvl := 1
 
-	for !(s.char == '*' && s.peek() == '/' && nestingLvl == 0) {
+	for !(s.char == '*' && s.peek() == '/' && nestingLvl == 0) && s.char >= 0 {
 		s.next()
 		if s.char == '/' && s.peek() == '*' {
 			nestingLvl++
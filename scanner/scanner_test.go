@@ -314,6 +314,20 @@ func TestIdentifierDelimited(t *testing.T) {
 	}
 }
 
+// Tests for scanning delimited identifiers with escaped closing delimiters -
+// [a]]b] or "say ""hi""".
+func TestIdentifierEscaped(t *testing.T) {
+	tests := []string{"[a]]b]", `"say ""hi"""`, "[]]]"}
+
+	for _, src := range tests {
+		var s Scanner
+		s.Init("s", []byte(src+" x"))
+		if tok, lit := s.Scan(); tok != token.IDENT || lit != src {
+			t.Errorf("Expected identifier <%s>, got %s <%s>", src, tok, lit)
+		}
+	}
+}
+
 // Tests for scanning regular identifiers in T-SQL.
 func TestIdentifierRegular(t *testing.T) {
 	src1 := []byte("VarNa#m3")
@@ -377,3 +391,128 @@ func TestPeek(t *testing.T) {
 		t.Errorf("Expected 'L', got: '%s'", string(s.peek()))
 	}
 }
+
+// Test for scanning delimited identifiers, Unicode strings and comparison
+// operators with Scan method.
+func TestScanDelimitedAndOperators(t *testing.T) {
+	src := []byte("[dbo].[Table] N'ąę' n'' \"x\" a<>b <= >= != < > 'it''s'")
+	var s Scanner
+	s.Init("s", src)
+
+	expToks := []token.Token{token.IDENT, token.PERIOD, token.IDENT,
+		token.STRING, token.STRING, token.IDENT, token.IDENT, token.NEQ,
+		token.IDENT, token.LEQ, token.GEQ, token.NEQ, token.LSS, token.GTR,
+		token.STRING}
+	expLits := []string{"[dbo]", ".", "[Table]", "N'ąę'", "n''", `"x"`, "a",
+		"<>", "b", "<=", ">=", "!=", "<", ">", "'it''s'"}
+
+	for id := range expToks {
+		tok, lit := s.Scan()
+		if tok != expToks[id] || lit != expLits[id] {
+			t.Errorf("Expected [%s] <%s>, got [%s] <%s>", expToks[id],
+				expLits[id], tok, lit)
+		}
+	}
+	if tok, _ := s.Scan(); tok != token.EOF {
+		t.Errorf("Expected EOF, got: [%s]", tok)
+	}
+}
+
+// Test for scanning signs before numbers. Sign is always a separate token,
+// only exponent can contain a sign.
+func TestScanSignedNumber(t *testing.T) {
+	src := []byte("@i-1 1+2 -3 1e+5")
+	var s Scanner
+	s.Init("s", src)
+
+	expToks := []token.Token{token.IDENT, token.SUB, token.INT, token.INT,
+		token.ADD, token.INT, token.SUB, token.INT, token.INT}
+	expLits := []string{"@i", "-", "1", "1", "+", "2", "-", "3", "1e+5"}
+
+	for id := range expToks {
+		tok, lit := s.Scan()
+		if tok != expToks[id] || lit != expLits[id] {
+			t.Errorf("Expected [%s] <%s>, got [%s] <%s>", expToks[id],
+				expLits[id], tok, lit)
+		}
+	}
+}
+
+// Test for scanning first word of multi-word keyword which isn't followed by
+// the rest of the keyword.
+func TestScanMultiwordPrefix(t *testing.T) {
+	src := []byte("WITHIN GROUP (ORDER BY x) group  by")
+	var s Scanner
+	s.Init("s", src)
+
+	expToks := []token.Token{token.WITHIN, token.IDENT, token.LPAREN,
+		token.ORDERBY, token.IDENT, token.RPAREN, token.GROUPBY}
+	expLits := []string{"WITHIN", "GROUP", "(", "ORDER BY", "x", ")",
+		"group by"}
+
+	for id := range expToks {
+		tok, lit := s.Scan()
+		if tok != expToks[id] || lit != expLits[id] {
+			t.Errorf("Expected [%s] <%s>, got [%s] <%s>", expToks[id],
+				expLits[id], tok, lit)
+		}
+	}
+}
+
+// Test for scanning unterminated strings, identifiers and comments. Scanner
+// should stop at the end of the source.
+func TestScanUnterminated(t *testing.T) {
+	srcs := []string{"'abc", "[abc", `"abc`, "/* abc", "-- abc"}
+
+	for _, src := range srcs {
+		var s Scanner
+		s.Init("s", []byte(src))
+		_, lit := s.Scan()
+		if lit != src {
+			t.Errorf("Expected literal <%s>, got <%s>", src, lit)
+		}
+		if tok, _ := s.Scan(); tok != token.EOF {
+			t.Errorf("Expected EOF after <%s>, got: [%s]", src, tok)
+		}
+	}
+}
+
+// Test for positions of scanned tokens. Columns are counted in characters,
+// multi-word keywords start at the position of their first word.
+func TestScanPosition(t *testing.T) {
+	src := "SELECT a, 'ł'\n  -- note\nFROM t\r\n\tGROUP  BY b"
+	var s Scanner
+	s.Init("file.sql", []byte(src))
+
+	expPos := []string{"file.sql:1:1", "file.sql:1:8", "file.sql:1:9",
+		"file.sql:1:11", "file.sql:2:3", "file.sql:3:1", "file.sql:3:6",
+		"file.sql:4:2", "file.sql:4:12"}
+	for _, exp := range expPos {
+		s.Scan()
+		if pos := s.Pos().String(); pos != exp {
+			t.Errorf("Expected position %s, got: %s", exp, pos)
+		}
+	}
+}
+
+// Test for scanning hexadecimal binary constants, money constants and
+// pseudo-columns starting with '$' sign. Each of them is a single word.
+func TestScanHexAndMoney(t *testing.T) {
+	src := []byte("0x01 0xFF&0X1a 0x $100 $.5 $1.25e2 $action 0 x")
+	var s Scanner
+	s.Init("s", src)
+
+	expToks := []token.Token{token.INT, token.INT, token.ILLEGAL, token.INT,
+		token.INT, token.INT, token.FLOAT, token.FLOAT, token.IDENT, token.INT,
+		token.IDENT}
+	expLits := []string{"0x01", "0xFF", "&", "0X1a", "0x", "$100", "$.5",
+		"$1.25e2", "$action", "0", "x"}
+
+	for id := range expToks {
+		tok, lit := s.Scan()
+		if tok != expToks[id] || lit != expLits[id] {
+			t.Errorf("Expected [%s] <%s>, got [%s] <%s>", expToks[id],
+				expLits[id], tok, lit)
+		}
+	}
+}
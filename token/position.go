@@ -2,7 +2,7 @@ package token
 
 import "strconv"
 
-// TODO...
+// Position describes location of a word in T-SQL script.
 type Position struct {
 	FileName string
 	Line     int // starting at 1
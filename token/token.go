@@ -45,6 +45,7 @@ const (
 	FULL
 	INNER
 	CROSS
+	OUTER
 	joinTypeEnd
 
 	HAVING
@@ -113,6 +114,52 @@ const (
 	NOT
 	WITH
 	OPTION
+	FOR
+	NULL
+	CREATE
+	ALTER
+	PROC
+	PROCEDURE
+	BEGIN
+	FUNCTION
+	RETURN
+	TABLE
+	VIEW
+	TRIGGER
+	UNION
+	EXCEPT
+	INTERSECT
+	CHECK
+	ASC
+	DESC
+	CONSTRAINT
+	PRIMARY
+	KEY
+	FOREIGN
+	REFERENCES
+	UNIQUE
+	DEFAULT
+	IDENTITY
+	CLUSTERED
+	NONCLUSTERED
+	COLLATE
+	DROP
+	COLUMN
+	IF
+	EXISTS
+	ELSE
+	WHILE
+	BREAK
+	CONTINUE
+	GOTO
+	CURSOR
+	OPEN
+	CLOSE
+	FETCH
+	DEALLOCATE
+	OVER
+	WITHIN
+	VALUES
 	keywordEnd
 
 	operatorBeg
@@ -139,6 +186,7 @@ const (
 	COMMA        // ,
 	PERIOD       // .
 	SEMICOLON    // ;
+	COLON        // :
 	SINGLEQUOTE  // '
 	DOUBLEQUOTES // "
 	operatorEnd
@@ -146,6 +194,7 @@ const (
 
 var tokens = [...]string{
 	EOF:     "EOF",
+	ILLEGAL: "ILLEGAL",
 	COMMENT: "COMMENT",
 
 	IDENT:  "IDENT",
@@ -172,6 +221,7 @@ var tokens = [...]string{
 	FULL:        "FULL",
 	INNER:       "INNER",
 	CROSS:       "CROSS",
+	OUTER:       "OUTER",
 	HAVING:      "HAVING",
 	INTO:        "INTO",
 	CASE:        "CASE",
@@ -238,6 +288,52 @@ var tokens = [...]string{
 	ALL:                   "ALL",
 	LIKE:                  "LIKE",
 	SOME:                  "SOME",
+	FOR:                   "FOR",
+	NULL:                  "NULL",
+	CREATE:                "CREATE",
+	ALTER:                 "ALTER",
+	PROC:                  "PROC",
+	PROCEDURE:             "PROCEDURE",
+	BEGIN:                 "BEGIN",
+	FUNCTION:              "FUNCTION",
+	RETURN:                "RETURN",
+	TABLE:                 "TABLE",
+	VIEW:                  "VIEW",
+	TRIGGER:               "TRIGGER",
+	UNION:                 "UNION",
+	EXCEPT:                "EXCEPT",
+	INTERSECT:             "INTERSECT",
+	CHECK:                 "CHECK",
+	ASC:                   "ASC",
+	DESC:                  "DESC",
+	CONSTRAINT:            "CONSTRAINT",
+	PRIMARY:               "PRIMARY",
+	KEY:                   "KEY",
+	FOREIGN:               "FOREIGN",
+	REFERENCES:            "REFERENCES",
+	UNIQUE:                "UNIQUE",
+	DEFAULT:               "DEFAULT",
+	IDENTITY:              "IDENTITY",
+	CLUSTERED:             "CLUSTERED",
+	NONCLUSTERED:          "NONCLUSTERED",
+	COLLATE:               "COLLATE",
+	DROP:                  "DROP",
+	COLUMN:                "COLUMN",
+	IF:                    "IF",
+	EXISTS:                "EXISTS",
+	ELSE:                  "ELSE",
+	WHILE:                 "WHILE",
+	BREAK:                 "BREAK",
+	CONTINUE:              "CONTINUE",
+	GOTO:                  "GOTO",
+	CURSOR:                "CURSOR",
+	OPEN:                  "OPEN",
+	CLOSE:                 "CLOSE",
+	FETCH:                 "FETCH",
+	DEALLOCATE:            "DEALLOCATE",
+	OVER:                  "OVER",
+	WITHIN:                "WITHIN",
+	VALUES:                "VALUES",
 
 	ADD: "+",
 	SUB: "-",
@@ -261,6 +357,7 @@ var tokens = [...]string{
 	COMMA:        ",",
 	PERIOD:       ".",
 	SEMICOLON:    ";",
+	COLON:        ":",
 	SINGLEQUOTE:  "'",
 	DOUBLEQUOTES: `"`,
 }
@@ -335,6 +432,25 @@ func KeywordLookup(identifier string) Token {
 	return IDENT
 }
 
+// Inseparable returns true if words of tokens prev and curr, which touch each
+// other in the script, have to stay touching. Space between two literals or
+// keywords, next to an unknown character or inside a compound assignment
+// operator (like "+=") could change meaning of the script.
+func Inseparable(prev, curr Token) bool {
+	switch {
+	case prev == ILLEGAL || curr == ILLEGAL:
+		return true
+	case (prev.IsLiteral() || prev.IsKeyword()) &&
+		(curr.IsLiteral() || curr.IsKeyword()):
+		return true
+	}
+	switch prev {
+	case ADD, SUB, MUL, DIV, MOD:
+		return curr == ASSIGN
+	}
+	return false
+}
+
 // IsLiteral returns true for tokens which are defined as literals.
 func (t Token) IsLiteral() bool {
 	return literalBeg < t && t < literalEnd
@@ -345,6 +461,23 @@ func (t Token) IsKeyword() bool {
 	return keywordBeg < t && t < keywordEnd
 }
 
+// IsReserved returns true for keywords which are reserved in T-SQL. Other
+// keywords, like names of aggregate functions, table hints or ROWS, may be
+// used as names of columns or other objects.
+func (t Token) IsReserved() bool {
+	switch t {
+	case TIES, CUBE, ROLLUP, REPEATABLE, ROWS, FORCESEEK, FORCESCAN,
+		FORCESEEN, NOLOCK, NOWAIT, PAGLOCK, READCOMMITTED, READCOMMITTEDLOCK,
+		READPAST, READUNCOMMITTED, REPEATABLEREAD, ROWLOCK, SERIALIZABLE,
+		SNAPSHOT, SPATIAL_WINDOW_MAX_CELLS, TABLOCK, TABLOCKX, UPDLOCK, XLOCK,
+		APPROX_COUNT_DISTINCT, AVG, CHECKSUM_AGG, COUNT, COUNT_BIG, GROUPING,
+		GROUPING_ID, MAX, MIN, STDEV, STDEVP, STRING_AGG, SUM, VAR, VARP,
+		RECOMPILE:
+		return false
+	}
+	return t.IsKeyword()
+}
+
 func (t Token) IsJoinType() bool {
 	return joinTypeBeg < t && t < joinTypeEnd
 }
@@ -31,7 +31,7 @@ func TestKeywordLookup(t *testing.T) {
 }
 
 func TestJointTypes(t *testing.T) {
-	toks := []Token{INNER, LEFT, RIGHT, FULL, CROSS}
+	toks := []Token{INNER, LEFT, RIGHT, FULL, CROSS, OUTER}
 
 	for _, tok := range toks {
 		if !tok.IsJoinType() {
@@ -39,3 +39,43 @@ func TestJointTypes(t *testing.T) {
 		}
 	}
 }
+
+func TestInseparable(t *testing.T) {
+	type test struct {
+		prev, curr Token
+		want       bool
+	}
+
+	tests := []test{
+		test{INT, IDENT, true},
+		test{IDENT, SELECT, true},
+		test{ADD, ASSIGN, true},
+		test{ILLEGAL, LPAREN, true},
+		test{IDENT, ADD, false},
+		test{RPAREN, AS, false},
+		test{SUB, INT, false},
+	}
+
+	for _, tt := range tests {
+		if got := Inseparable(tt.prev, tt.curr); got != tt.want {
+			t.Errorf("Expected: [%v] for [%s %s], got: [%v]", tt.want,
+				tt.prev, tt.curr, got)
+		}
+	}
+}
+
+func TestReserved(t *testing.T) {
+	reserved := []Token{SELECT, LEFT, HOLDLOCK, INDEX, PERCENT, VALUES}
+	names := []Token{COUNT, MAX, ROWS, NOLOCK, ROLLUP, RECOMPILE, IDENT}
+
+	for _, tok := range reserved {
+		if !tok.IsReserved() {
+			t.Errorf("Expected reserved keyword: [%s]", tok)
+		}
+	}
+	for _, tok := range names {
+		if tok.IsReserved() {
+			t.Errorf("Expected not reserved keyword: [%s]", tok)
+		}
+	}
+}